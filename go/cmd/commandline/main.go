@@ -11,26 +11,35 @@ import (
 )
 
 
+// main prints the response of the command. A compliance check with violations
+// or errors ends with exit code 1 for CI pipelines.
 func main() {
+	response := run()
+	println(string(response))
+	os.Exit(command.ExitCode(os.Args, response))
+}
+
+func run() []byte {
 	argCount := len(os.Args)
 	if argCount == 1 {
-		println(string(usecases.FormatJSON(nil, errors.New("no command provided"))))
-		return
+		return usecases.FormatJSON(nil, errors.New("no command provided"))
 	}
 
 	path, err := infra.ParseDatabasePath(os.Args)
 	if err != nil {
-		println(string(usecases.FormatJSON(nil, err)))
-		return
+		return usecases.FormatJSON(nil, err)
+	}
+
+	seeds, err := command.ParseSeeds(os.Args)
+	if err != nil {
+		return usecases.FormatJSON(nil, err)
 	}
 
-	storage, err := infra.NewStorage(path)
+	storage, err := infra.NewStorage(path, seeds...)
 	if err != nil {
-		println(string(usecases.FormatJSON(nil, err)))
-		return
+		return usecases.FormatJSON(nil, err)
 	}
 	defer storage.CloseDatabase()
 
-	println(string(command.ParseCommand(storage, os.Args)))
+	return []byte(command.ParseCommand(storage, os.Args))
 }
-
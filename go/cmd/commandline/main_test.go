@@ -1,10 +1,17 @@
 package main
 
 import (
+	"crypto/sha256"
+	"database/sql"
+	"encoding/hex"
 	"encoding/json"
 	"errors"
 	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/marcosbitetti/godot-manage-attribuitions-plugin/intrenal/domain"
 	"github.com/marcosbitetti/godot-manage-attribuitions-plugin/intrenal/infra"
@@ -302,6 +309,1493 @@ func TestMain(t *testing.T) {
 		assert.NoError(t, json.Unmarshal([]byte(jsonRaw), &dataAttribuitions))
 		assert.Equal(t, 0, len(dataAttribuitions.Data))
 	})
+
+	t.Run("should import attribuitions from REUSE metadata", func(t *testing.T) {
+		tempDir := t.TempDir()
+		databasePath := tempDir + "/nonexistent.db"
+		projectPath := tempDir + "/game"
+
+		writeProjectFile(t, projectPath, "REUSE.toml", `version = 1
+
+[[annotations]]
+path = ["music/**"]
+SPDX-FileCopyrightText = "2020 Ana Music <ana@example.com>"
+SPDX-License-Identifier = "CC-BY-4.0"
+`)
+		writeProjectFile(t, projectPath, "music/theme.ogg", "")
+		writeProjectFile(t, projectPath, "art/hero.png", "")
+		writeProjectFile(t, projectPath, "art/hero.png.license", "SPDX-FileCopyrightText: © 2021 Bob Pixel\nSPDX-License-Identifier: CC0-1.0\n")
+		writeProjectFile(t, projectPath, "scripts/camera.gd", "# SPDX-FileCopyrightText: 2019-2022 Carl Coder\n# SPDX-License-Identifier: MIT\nextends Camera3D\n")
+
+		os.Args = []string{"app", databasePath, "importReuse", `{"path":"` + projectPath + `"}`}
+		jsonRaw := fakeMain()
+		assert.Contains(t, jsonRaw, "success", jsonRaw)
+		var report _ResponseImport
+		assert.NoError(t, json.Unmarshal([]byte(jsonRaw), &report))
+		assert.Equal(t, 3, len(report.Data.Created))
+		assert.Equal(t, 0, len(report.Data.Conflicts))
+
+		os.Args = []string{"app", databasePath, "listAttribuitions"}
+		jsonRaw = fakeMain()
+		var dataAttribuitions _ResponseAttribuition
+		assert.NoError(t, json.Unmarshal([]byte(jsonRaw), &dataAttribuitions))
+		assert.Equal(t, 3, len(dataAttribuitions.Data))
+		assert.Equal(t, "camera.gd", dataAttribuitions.Data[0].Name)
+		assert.Equal(t, "Carl Coder", dataAttribuitions.Data[0].Author)
+		assert.Equal(t, "MIT", dataAttribuitions.Data[0].Licence)
+		assert.Equal(t, "res://art/hero.png", dataAttribuitions.Data[1].FileName)
+		assert.Equal(t, "Bob Pixel", dataAttribuitions.Data[1].Author)
+		assert.Equal(t, "Ana Music", dataAttribuitions.Data[2].Author)
+		assert.Equal(t, "Attribution 4.0 International (CC BY 4.0)", dataAttribuitions.Data[2].Licence)
+	})
+
+	t.Run("should report REUSE conflicts instead of overwrite", func(t *testing.T) {
+		tempDir := t.TempDir()
+		databasePath := tempDir + "/nonexistent.db"
+		projectPath := tempDir + "/game"
+
+		writeProjectFile(t, projectPath, ".reuse/dep5", `Format: https://www.debian.org/doc/packaging-manuals/copyright-format/1.0/
+
+Files: sfx/*
+Copyright: 2018 Dana Sound
+License: CC-BY-SA-3.0
+`)
+		writeProjectFile(t, projectPath, "sfx/jump.wav", "")
+
+		os.Args = []string{"app", databasePath, "addAttribuition",
+			`{"name":"Jump","filename":"res://sfx/jump.wav","author":"Other","link":"http://none","licence":"MIT","type":"Sound Effect"}`}
+		jsonRaw := fakeMain()
+		assert.Contains(t, jsonRaw, "success")
+
+		os.Args = []string{"app", databasePath, "importReuse", `{"path":"` + projectPath + `"}`}
+		jsonRaw = fakeMain()
+		assert.Contains(t, jsonRaw, "success", jsonRaw)
+		var report _ResponseImport
+		assert.NoError(t, json.Unmarshal([]byte(jsonRaw), &report))
+		assert.Equal(t, 0, len(report.Data.Created))
+		assert.Equal(t, 2, len(report.Data.Conflicts))
+		assert.Equal(t, "licence", report.Data.Conflicts[0].Field)
+
+		os.Args = []string{"app", databasePath, "listAttribuitions"}
+		jsonRaw = fakeMain()
+		var dataAttribuitions _ResponseAttribuition
+		assert.NoError(t, json.Unmarshal([]byte(jsonRaw), &dataAttribuitions))
+		assert.Equal(t, "MIT", dataAttribuitions.Data[0].Licence)
+	})
+	t.Run("should export attribuitions as DEP-5 and import them back", func(t *testing.T) {
+		tempDir := t.TempDir()
+		databasePath := tempDir + "/nonexistent.db"
+		copyrightPath := tempDir + "/debian/copyright"
+
+		os.Args = []string{"app", databasePath, "addAttribuition",
+			`{"name":"Hero","filename":"res://art/hero.png","author":"Bob Pixel","link":"Bob Pixel","licence":"Attribution 4.0 International (CC BY 4.0)","type":"Texture"}`}
+		assert.Contains(t, fakeMain(), "success")
+		os.Args = []string{"app", databasePath, "addAttribuition",
+			`{"name":"Enemy","filename":"res://art/enemy.png","author":"Bob Pixel","link":"Bob Pixel","licence":"Attribution 4.0 International (CC BY 4.0)","type":"Texture"}`}
+		assert.Contains(t, fakeMain(), "success")
+		os.Args = []string{"app", databasePath, "addLicence", `{"name": "Insaneware", "link": "https://example.com/license"}`}
+		assert.Contains(t, fakeMain(), "success")
+		os.Args = []string{"app", databasePath, "addAttribuition",
+			`{"name":"Theme","filename":"res://music/theme song.ogg","author":"Ana Music","link":"Ana Music","licence":"Insaneware","type":"Music"}`}
+		assert.Contains(t, fakeMain(), "success")
+
+		os.Args = []string{"app", databasePath, "addLicence", `{"name": "Unlisted Licence", "link": "https://example.com/unlisted"}`}
+		assert.Contains(t, fakeMain(), "success")
+		os.Args = []string{"app", databasePath, "addAttribuition",
+			`{"name":"Main Menu Jingle","author":"Ana Music","link":"Ana Music","licence":"Unlisted Licence","type":"Music"}`}
+		assert.Contains(t, fakeMain(), "success")
+
+		os.Args = []string{"app", databasePath, "exportDep5", `{"upstreamName":"My Game"}`}
+		jsonRaw := fakeMain()
+		assert.Contains(t, jsonRaw, "success", jsonRaw)
+		var document _ResponseText
+		assert.NoError(t, json.Unmarshal([]byte(jsonRaw), &document))
+		assert.Contains(t, document.Data, "Upstream-Name: My Game")
+		assert.Contains(t, document.Data, "Files: art/enemy.png\n art/hero.png\nCopyright: Bob Pixel\nLicense: CC-BY-4.0")
+		assert.Contains(t, document.Data, "Files: music/theme?song.ogg\nCopyright: Ana Music\nLicense: Insaneware")
+		assert.Contains(t, document.Data, "\nLicense: Insaneware\n Insaneware\n .\n https://example.com/license\n")
+		assert.Contains(t, document.Data, "Comment: Credits without a file name, not listed below:\n Main Menu Jingle\n")
+		assert.NotContains(t, document.Data, "Files: \n")
+		assert.NotContains(t, document.Data, "Unlisted Licence")
+
+		os.Args = []string{"app", databasePath, "exportDep5", `{"output":"` + copyrightPath + `"}`}
+		assert.Contains(t, fakeMain(), "success")
+
+		otherDatabasePath := tempDir + "/other.db"
+		os.Args = []string{"app", otherDatabasePath, "importDep5", `{"path":"` + copyrightPath + `"}`}
+		jsonRaw = fakeMain()
+		assert.Contains(t, jsonRaw, "success", jsonRaw)
+		var report _ResponseImport
+		assert.NoError(t, json.Unmarshal([]byte(jsonRaw), &report))
+		assert.Equal(t, []string{"res://art/enemy.png", "res://art/hero.png"}, report.Data.Created)
+		assert.Equal(t, 1, len(report.Data.Conflicts))
+		assert.Equal(t, "music/theme?song.ogg", report.Data.Conflicts[0].FileName)
+
+		os.Args = []string{"app", otherDatabasePath, "listLicences"}
+		var dataLicences _ResponseLicence
+		assert.NoError(t, json.Unmarshal([]byte(fakeMain()), &dataLicences))
+		assertHasLicence(t, "Insaneware", dataLicences.Data)
+
+		os.Args = []string{"app", otherDatabasePath, "listAttribuitions"}
+		var dataAttribuitions _ResponseAttribuition
+		assert.NoError(t, json.Unmarshal([]byte(fakeMain()), &dataAttribuitions))
+		assert.Equal(t, 2, len(dataAttribuitions.Data))
+		assert.Equal(t, "Bob Pixel", dataAttribuitions.Data[0].Author)
+		assert.Equal(t, "Attribution 4.0 International (CC BY 4.0)", dataAttribuitions.Data[0].Licence)
+	})
+
+	t.Run("should write a DEP-5 copyright line per author", func(t *testing.T) {
+		tempDir := t.TempDir()
+		databasePath := tempDir + "/nonexistent.db"
+		copyrightPath := tempDir + "/debian/copyright"
+
+		os.Args = []string{"app", databasePath, "addAttribuition",
+			`{"name":"Duet","filename":"res://music/duet.ogg","authors":[{"name":"Ana Music"},{"name":"Bob Pixel"}],"link":"https://example.com/duet","licence":"MIT","type":"Music"}`}
+		assert.Contains(t, fakeMain(), "success")
+		os.Args = []string{"app", databasePath, "addType", `{"name":"Found Sound","requires":["link"]}`}
+		assert.Contains(t, fakeMain(), "success")
+		os.Args = []string{"app", databasePath, "addAttribuition",
+			`{"name":"Wind","filename":"res://sfx/wind.ogg","link":"https://example.com/wind","licence":"MIT","type":"Found Sound"}`}
+		assert.Contains(t, fakeMain(), "success")
+
+		os.Args = []string{"app", databasePath, "exportDep5", `{"output":"` + copyrightPath + `"}`}
+		assert.Contains(t, fakeMain(), "success")
+		content, err := os.ReadFile(copyrightPath)
+		assert.NoError(t, err)
+		assert.Contains(t, string(content), "Files: music/duet.ogg\nCopyright: Ana Music\n Bob Pixel\nLicense: MIT")
+		assert.Contains(t, string(content), "Files: sfx/wind.ogg\nCopyright: Unknown\nLicense: MIT")
+
+		otherDatabasePath := tempDir + "/other.db"
+		os.Args = []string{"app", otherDatabasePath, "importDep5", `{"path":"` + copyrightPath + `","type":"Music"}`}
+		jsonRaw := fakeMain()
+		assert.Contains(t, jsonRaw, "success", jsonRaw)
+		os.Args = []string{"app", otherDatabasePath, "listAttribuitions"}
+		var dataAttribuitions _ResponseAttribuition
+		assert.NoError(t, json.Unmarshal([]byte(fakeMain()), &dataAttribuitions))
+		authors := make(map[string]string)
+		for _, attribuition := range dataAttribuitions.Data {
+			authors[attribuition.FileName] = attribuition.Author
+		}
+		assert.Equal(t, map[string]string{"res://music/duet.ogg": "Ana Music, Bob Pixel", "res://sfx/wind.ogg": ""}, authors)
+		os.Args = []string{"app", otherDatabasePath, "listAuthors"}
+		assert.NotContains(t, fakeMain(), "Unknown")
+	})
+
+	t.Run("should export third-party notices", func(t *testing.T) {
+		tempDir := t.TempDir()
+		databasePath := tempDir + "/nonexistent.db"
+
+		os.Args = []string{"app", databasePath, "addAttribuition",
+			`{"name":"Hero","filename":"res://art/hero.png","author":"Bob Pixel","link":"Bob Pixel","licence":"MIT","type":"Texture"}`}
+		assert.Contains(t, fakeMain(), "success")
+		os.Args = []string{"app", databasePath, "addAttribuition",
+			`{"name":"Enemy","filename":"res://art/enemy.png","author":"Bob Pixel","link":"Bob Pixel","licence":"MIT","type":"Texture"}`}
+		assert.Contains(t, fakeMain(), "success")
+		os.Args = []string{"app", databasePath, "addAttribuition",
+			`{"name":"Theme","filename":"res://music/theme.ogg","author":"Ana Music","link":"Ana Music","licence":"Open Font License (OFL)","type":"Music"}`}
+		assert.Contains(t, fakeMain(), "success")
+
+		os.Args = []string{"app", databasePath, "exportNotices"}
+		jsonRaw := fakeMain()
+		assert.Contains(t, jsonRaw, "success", jsonRaw)
+		var document _ResponseText
+		assert.NoError(t, json.Unmarshal([]byte(jsonRaw), &document))
+		assert.Equal(t, 1, strings.Count(document.Data, "\nMIT\n"))
+		assert.Contains(t, document.Data, "  - Enemy by Bob Pixel (res://art/enemy.png)\n")
+		assert.Contains(t, document.Data, "  - Hero by Bob Pixel (res://art/hero.png)\n")
+
+		exportPath := tempDir + "/export"
+		os.Args = []string{"app", databasePath, "exportNotices",
+			`{"output":"` + exportPath + `/THIRD_PARTY_NOTICES.txt","directory":"` + exportPath + `/licences"}`}
+		assert.Contains(t, fakeMain(), "success")
+		content, err := os.ReadFile(exportPath + "/THIRD_PARTY_NOTICES.txt")
+		assert.NoError(t, err)
+		assert.Equal(t, document.Data, string(content))
+		content, err = os.ReadFile(exportPath + "/licences/OFL-1.1.txt")
+		assert.NoError(t, err)
+		assert.Contains(t, string(content), "  - Theme by Ana Music (res://music/theme.ogg)\n")
+		_, err = os.Stat(exportPath + "/licences/MIT.txt")
+		assert.NoError(t, err)
+	})
+
+	t.Run("should store licence texts", func(t *testing.T) {
+		tempDir := t.TempDir()
+		databasePath := tempDir + "/nonexistent.db"
+
+		os.Args = []string{"app", databasePath, "getLicenceText", `{"name":"MIT"}`}
+		jsonRaw := fakeMain()
+		assert.Contains(t, jsonRaw, "success", jsonRaw)
+		assert.Contains(t, jsonRaw, "Permission is hereby granted, free of charge")
+
+		os.Args = []string{"app", databasePath, "addLicence",
+			`{"name":"Insaneware","link":"https://example.com/license","text":"Do crazy things.","summary":"Crazy"}`}
+		assert.Contains(t, fakeMain(), "success")
+		os.Args = []string{"app", databasePath, "listLicences"}
+		var dataLicences _ResponseLicence
+		assert.NoError(t, json.Unmarshal([]byte(fakeMain()), &dataLicences))
+		var id int64
+		for _, licence := range dataLicences.Data {
+			assert.Empty(t, licence.Text)
+			if licence.Name == "Insaneware" {
+				id = licence.Id
+				assert.Equal(t, "Crazy", licence.Summary)
+			}
+		}
+
+		os.Args = []string{"app", databasePath, "updateLicence",
+			`{"_id":` + strconv.FormatInt(id, 10) + `,"name":"Insaneware 2","link":"https://example.com/license"}`}
+		assert.Contains(t, fakeMain(), "success")
+		os.Args = []string{"app", databasePath, "getLicenceText", `{"_id":` + strconv.FormatInt(id, 10) + `}`}
+		var text _ResponseText
+		assert.NoError(t, json.Unmarshal([]byte(fakeMain()), &text))
+		assert.Equal(t, "Do crazy things.", text.Data)
+
+		os.Args = []string{"app", databasePath, "getLicenceText", `{"name":"Royalty Free"}`}
+		assert.Contains(t, fakeMain(), "licence text not stored")
+		os.Args = []string{"app", databasePath, "getLicenceText", `{"name":"Attribution 4.0 International (CC BY 4.0)"}`}
+		assert.NoError(t, json.Unmarshal([]byte(fakeMain()), &text))
+		assert.Contains(t, text.Data, "Creative Commons Attribution 4.0 International Public License")
+		os.Args = []string{"app", databasePath, "getLicenceText", `{"name":"Attribution-NonCommercial-NoDerivs 3.0 Unported (CC BY-NC-ND 3.0)"}`}
+		assert.NoError(t, json.Unmarshal([]byte(fakeMain()), &text))
+		assert.Contains(t, text.Data, "Attribution-NonCommercial-NoDerivs 3.0 Unported")
+	})
+
+	t.Run("should upgrade databases created by older versions", func(t *testing.T) {
+		tempDir := t.TempDir()
+		databasePath := tempDir + "/old.db"
+		content, err := os.ReadFile("testdata/baseline.db")
+		assert.NoError(t, err)
+		assert.NoError(t, os.WriteFile(databasePath, content, 0o644))
+
+		os.Args = []string{"app", databasePath, "getLicenceText", `{"name":"MIT"}`}
+		jsonRaw := fakeMain()
+		assert.Contains(t, jsonRaw, "success", jsonRaw)
+		assert.Contains(t, jsonRaw, "Permission is hereby granted, free of charge")
+
+		os.Args = []string{"app", databasePath, "getLicenceText", `{"name":"Attribution-ShareAlike 3.0 Unported (CC BY-SA 3.0)"}`}
+		jsonRaw = fakeMain()
+		assert.Contains(t, jsonRaw, "success", jsonRaw)
+		assert.Contains(t, jsonRaw, "Attribution-ShareAlike 3.0 Unported")
+	})
+
+	t.Run("should add licences from the SPDX catalog", func(t *testing.T) {
+		tempDir := t.TempDir()
+		databasePath := tempDir + "/nonexistent.db"
+
+		os.Args = []string{"app", databasePath, "addLicence", `{"spdx":"zlib"}`}
+		assert.Contains(t, fakeMain(), "success")
+		os.Args = []string{"app", databasePath, "addLicence", `{"spdx":"Not-A-Licence"}`}
+		assert.Contains(t, fakeMain(), "invalid value")
+		os.Args = []string{"app", databasePath, "addLicence", `{"spdx":"cc-by-nc-3.0"}`}
+		assert.Contains(t, fakeMain(), "success")
+
+		os.Args = []string{"app", databasePath, "getLicenceText", `{"name":"zlib License"}`}
+		jsonRaw := fakeMain()
+		assert.Contains(t, jsonRaw, "success", jsonRaw)
+		assert.Contains(t, jsonRaw, "This software is provided 'as-is'")
+
+		os.Args = []string{"app", databasePath, "listLicences"}
+		var dataLicences _ResponseLicence
+		assert.NoError(t, json.Unmarshal([]byte(fakeMain()), &dataLicences))
+		for _, licence := range dataLicences.Data {
+			if licence.SpdxId == "CC-BY-NC-3.0" {
+				assert.False(t, licence.AllowsCommercial)
+			}
+			if licence.Name == "zlib License" {
+				assert.Equal(t, "Zlib", licence.SpdxId)
+				assert.Equal(t, "https://zlib.net/zlib_license.html", licence.Link)
+			}
+			if licence.Name == "Attribution-ShareAlike 3.0 Unported (CC BY-SA 3.0)" {
+				assert.Equal(t, "CC-BY-SA-3.0", licence.SpdxId)
+			}
+		}
+	})
+
+	t.Run("should match existing licences to SPDX ids", func(t *testing.T) {
+		tempDir := t.TempDir()
+		databasePath := tempDir + "/old.db"
+		content, err := os.ReadFile("testdata/baseline.db")
+		assert.NoError(t, err)
+		assert.NoError(t, os.WriteFile(databasePath, content, 0o644))
+
+		os.Args = []string{"app", databasePath, "addLicence", `{"name":"Boost Software License 1.0","link":"https://www.boost.org/LICENSE_1_0.txt"}`}
+		assert.Contains(t, fakeMain(), "success")
+		os.Args = []string{"app", databasePath, "addLicence", `{"name":"Academic Free License v3.0","link":"https://opensource.org/licenses/AFL-3.0"}`}
+		assert.Contains(t, fakeMain(), "success")
+		os.Args = []string{"app", databasePath, "addLicence", `{"name":"Insaneware","link":"https://example.com/license"}`}
+		assert.Contains(t, fakeMain(), "success")
+
+		os.Args = []string{"app", databasePath, "matchSpdx"}
+		jsonRaw := fakeMain()
+		assert.Contains(t, jsonRaw, "success", jsonRaw)
+		var report struct {
+			Data struct {
+				Matched   []domain.Licence `json:"matched"`
+				Unmatched []domain.Licence `json:"unmatched"`
+			} `json:"data"`
+		}
+		assert.NoError(t, json.Unmarshal([]byte(jsonRaw), &report))
+		assert.Equal(t, 2, len(report.Data.Matched))
+		matched := map[string]string{}
+		for _, licence := range report.Data.Matched {
+			matched[licence.Name] = licence.SpdxId
+		}
+		assert.Equal(t, "BSL-1.0", matched["Boost Software License 1.0"])
+		assert.Equal(t, "AFL-3.0", matched["Academic Free License v3.0"])
+		assertHasLicence(t, "Insaneware", report.Data.Unmatched)
+		assertHasLicence(t, "Royalty Free", report.Data.Unmatched)
+
+		os.Args = []string{"app", databasePath, "listLicences"}
+		var dataLicences _ResponseLicence
+		assert.NoError(t, json.Unmarshal([]byte(fakeMain()), &dataLicences))
+		for _, licence := range dataLicences.Data {
+			if licence.Name == "Open Font License (OFL)" {
+				assert.Equal(t, "OFL-1.1", licence.SpdxId)
+			}
+		}
+	})
+
+	t.Run("should describe licence terms", func(t *testing.T) {
+		tempDir := t.TempDir()
+		databasePath := tempDir + "/old.db"
+		content, err := os.ReadFile("testdata/baseline.db")
+		assert.NoError(t, err)
+		assert.NoError(t, os.WriteFile(databasePath, content, 0o644))
+
+		os.Args = []string{"app", databasePath, "addLicence", `{"name":"Insaneware","link":"https://example.com/license"}`}
+		assert.Contains(t, fakeMain(), "success")
+
+		os.Args = []string{"app", databasePath, "listLicences"}
+		var dataLicences _ResponseLicence
+		assert.NoError(t, json.Unmarshal([]byte(fakeMain()), &dataLicences))
+		var insaneware domain.Licence
+		for _, licence := range dataLicences.Data {
+			switch licence.Name {
+			case "Attribution-NonCommercial 4.0 International (CC BY-NC 4.0)":
+				assert.False(t, licence.AllowsCommercial)
+				assert.True(t, licence.AllowsDerivatives)
+				assert.True(t, licence.RequiresAttribution)
+			case "GNU General Public Licence":
+				assert.True(t, licence.ShareAlike)
+				assert.Equal(t, domain.CopyleftProject, licence.CopyleftScope)
+			case "Insaneware":
+				insaneware = licence
+			}
+		}
+		assert.True(t, insaneware.AllowsCommercial)
+		assert.Equal(t, domain.CopyleftNone, insaneware.CopyleftScope)
+
+		os.Args = []string{"app", databasePath, "updateLicence", `{"_id":` + strconv.FormatInt(insaneware.Id, 10) + `,"name":"Insaneware","link":"https://example.com/license","copyleftScope":"everything"}`}
+		assert.Contains(t, fakeMain(), "invalid value")
+		os.Args = []string{"app", databasePath, "updateLicence", `{"_id":` + strconv.FormatInt(insaneware.Id, 10) + `,"name":"Insaneware","link":"https://example.com/license","allowsCommercial":false,"shareAlike":true,"copyleftScope":"derivative"}`}
+		assert.Contains(t, fakeMain(), "success")
+		os.Args = []string{"app", databasePath, "updateLicence", `{"_id":` + strconv.FormatInt(insaneware.Id, 10) + `,"name":"Insaneware","link":"https://example.com/licenses"}`}
+		assert.Contains(t, fakeMain(), "success")
+
+		os.Args = []string{"app", databasePath, "addAttribuition", `{"name":"Crazy","filename":"crazy.ogg","type":"Music","author":"Ze","link":"Ze","licence":"Insaneware"}`}
+		assert.Contains(t, fakeMain(), "success")
+		os.Args = []string{"app", databasePath, "listAttribuitions"}
+		var dataAttribuitions _ResponseAttribuition
+		assert.NoError(t, json.Unmarshal([]byte(fakeMain()), &dataAttribuitions))
+		assert.Equal(t, 1, len(dataAttribuitions.Data))
+		terms := dataAttribuitions.Data[0].LicenceTerms
+		assert.False(t, terms.AllowsCommercial)
+		assert.True(t, terms.ShareAlike)
+		assert.Equal(t, domain.CopyleftDerivative, terms.CopyleftScope)
+
+		os.Args = []string{"app", databasePath, "updateLicence", `{"_id":` + strconv.FormatInt(insaneware.Id, 10) + `,"name":"Insaneware","link":"https://example.com/licenses","spdx":"mit"}`}
+		assert.Contains(t, fakeMain(), "success")
+		os.Args = []string{"app", databasePath, "listAttribuitions"}
+		assert.NoError(t, json.Unmarshal([]byte(fakeMain()), &dataAttribuitions))
+		assert.Equal(t, "MIT", dataAttribuitions.Data[0].LicenceSpdx)
+		terms = dataAttribuitions.Data[0].LicenceTerms
+		assert.True(t, terms.AllowsCommercial)
+		assert.False(t, terms.ShareAlike)
+		assert.Equal(t, domain.CopyleftNone, terms.CopyleftScope)
+	})
+
+	t.Run("should check compliance against the project profile", func(t *testing.T) {
+		tempDir := t.TempDir()
+		databasePath := tempDir + "/nonexistent.db"
+
+		os.Args = []string{"app", databasePath, "getProfile"}
+		var profile struct {
+			Data domain.ProjectProfile `json:"data"`
+		}
+		assert.NoError(t, json.Unmarshal([]byte(fakeMain()), &profile))
+		assert.True(t, profile.Data.Commercial)
+		assert.Equal(t, "", profile.Data.Licence)
+
+		os.Args = []string{"app", databasePath, "checkCompliance"}
+		jsonRaw := fakeMain()
+		assert.Contains(t, jsonRaw, "success", jsonRaw)
+		assert.False(t, usecases.Failed([]byte(jsonRaw)))
+
+		os.Args = []string{"app", databasePath, "addAttribuition", `{"name":"Song","filename":"song.ogg","type":"Music","author":"Ze","link":"Ze","licence":"Attribution-NonCommercial 4.0 International (CC BY-NC 4.0)"}`}
+		assert.Contains(t, fakeMain(), "success")
+		os.Args = []string{"app", databasePath, "addAttribuition", `{"name":"Script","filename":"script.gd","type":"Code Snippet","author":"Ze","link":"Ze","licence":"GNU General Public Licence"}`}
+		assert.Contains(t, fakeMain(), "success")
+
+		var report struct {
+			Status string                  `json:"status"`
+			Data   domain.ComplianceReport `json:"data"`
+		}
+		os.Args = []string{"app", databasePath, "checkCompliance"}
+		jsonRaw = fakeMain()
+		assert.True(t, usecases.Failed([]byte(jsonRaw)))
+		assert.NoError(t, json.Unmarshal([]byte(jsonRaw), &report))
+		assert.Equal(t, "failure", report.Status)
+		assert.Equal(t, 2, report.Data.Checked)
+		assert.Equal(t, 2, len(report.Data.Violations))
+		assert.Equal(t, usecases.RuleCopyleft, report.Data.Violations[0].Rule)
+		assert.Equal(t, "script.gd", report.Data.Violations[0].FileName)
+		assert.Equal(t, usecases.RuleNonCommercial, report.Data.Violations[1].Rule)
+		assert.Equal(t, "song.ogg", report.Data.Violations[1].FileName)
+
+		os.Args = []string{"app", databasePath, "updateProfile", `{"licence":"Not-A-Licence"}`}
+		assert.Contains(t, fakeMain(), "invalid value")
+		os.Args = []string{"app", databasePath, "updateProfile", `{"commercial":false,"licence":"gpl-3.0-or-later","platforms":["Linux","Web"]}`}
+		assert.Contains(t, fakeMain(), "success")
+		os.Args = []string{"app", databasePath, "getProfile"}
+		assert.NoError(t, json.Unmarshal([]byte(fakeMain()), &profile))
+		assert.Equal(t, []string{"Linux", "Web"}, profile.Data.Platforms)
+		assert.Equal(t, "GPL-3.0-or-later", profile.Data.Licence)
+
+		os.Args = []string{"app", databasePath, "checkCompliance"}
+		jsonRaw = fakeMain()
+		assert.False(t, usecases.Failed([]byte(jsonRaw)), jsonRaw)
+	})
+
+	t.Run("should compute TASL attribution texts", func(t *testing.T) {
+		tempDir := t.TempDir()
+		databasePath := tempDir + "/nonexistent.db"
+
+		os.Args = []string{"app", databasePath, "addAttribuition", `{"name":"Forest","filename":"forest.png","type":"Texture","author":"Ana","link":"https://example.com/forest","licence":"Attribution 4.0 International (CC BY 4.0)"}`}
+		assert.Contains(t, fakeMain(), "success")
+		os.Args = []string{"app", databasePath, "addAttribuition", `{"name":"Rain","filename":"rain.ogg","type":"Music","author":"Bia","link":"https://example.com/rain","licence":"CC0 1.0 Universal (CC0 1.0) - Public Domain Dedication"}`}
+		assert.Contains(t, fakeMain(), "success")
+
+		os.Args = []string{"app", databasePath, "listAttribuitions"}
+		var dataAttribuitions _ResponseAttribuition
+		assert.NoError(t, json.Unmarshal([]byte(fakeMain()), &dataAttribuitions))
+		assert.Equal(t, 2, len(dataAttribuitions.Data))
+		forest := dataAttribuitions.Data[0]
+		assert.Equal(t, "https://example.com/forest", forest.Link)
+		assert.Equal(t, "“Forest” by Ana is licensed under CC BY 4.0", forest.AttributionText.Plain)
+		assert.Equal(t, "“[Forest](https://example.com/forest)” by Ana is licensed under [CC BY 4.0](https://creativecommons.org/licenses/by/4.0/)", forest.AttributionText.Markdown)
+		assert.Equal(t, `“<a href="https://example.com/forest">Forest</a>” by Ana is licensed under <a href="https://creativecommons.org/licenses/by/4.0/">CC BY 4.0</a>`, forest.AttributionText.Html)
+		assert.Equal(t, "“[url=https://example.com/forest]Forest[/url]” by Ana is licensed under [url=https://creativecommons.org/licenses/by/4.0/]CC BY 4.0[/url]", forest.AttributionText.BBCode)
+		assert.Equal(t, "“Rain” by Bia is marked with CC0 1.0", dataAttribuitions.Data[1].AttributionText.Plain)
+
+		os.Args = []string{"app", databasePath, "updateAttribuition", `{"_id":` + strconv.FormatInt(forest.Id, 10) + `,"name":"Forest","filename":"forest.png","type":"Texture","author":"Ana","link":"https://example.com/forest","licence":"Attribution 4.0 International (CC BY 4.0)","attributionOverride":"Art by {author} ({source})"}`}
+		assert.Contains(t, fakeMain(), "success")
+		os.Args = []string{"app", databasePath, "updateAttribuition", `{"_id":` + strconv.FormatInt(forest.Id, 10) + `,"name":"Forest","filename":"forest.png","type":"Texture","author":"Ana Maria","link":"https://example.com/forest","licence":"Attribution 4.0 International (CC BY 4.0)"}`}
+		assert.Contains(t, fakeMain(), "success")
+
+		os.Args = []string{"app", databasePath, "exportCredits", `{"format":"markdown"}`}
+		var document _ResponseText
+		assert.NoError(t, json.Unmarshal([]byte(fakeMain()), &document))
+		assert.Equal(t, "# Credits\n\n- Art by Ana Maria ([https://example.com/forest](https://example.com/forest))\n- “[Rain](https://example.com/rain)” by Bia is marked with [CC0 1.0](https://creativecommons.org/publicdomain/zero/1.0/)\n", document.Data)
+
+		os.Args = []string{"app", databasePath, "exportCredits", `{"format":"rtf"}`}
+		assert.Contains(t, fakeMain(), "invalid value")
+	})
+
+	t.Run("should track modified assets", func(t *testing.T) {
+		tempDir := t.TempDir()
+		databasePath := tempDir + "/nonexistent.db"
+
+		os.Args = []string{"app", databasePath, "addAttribuition", `{"name":"Forest","filename":"forest.png","type":"Texture","author":"Ana","link":"https://example.com/forest","licence":"Attribution 4.0 International (CC BY 4.0)","modified":true,"modificationNotes":"recoloured","upstreamLink":"https://example.com/forest.png"}`}
+		assert.Contains(t, fakeMain(), "success")
+		os.Args = []string{"app", databasePath, "addAttribuition", `{"name":"Statue","filename":"statue.glb","type":"3D Model","author":"Bia","link":"https://example.com/statue","licence":"Attribution-NoDerivatives 4.0 International (CC BY-ND 4.0)"}`}
+		assert.Contains(t, fakeMain(), "success")
+
+		os.Args = []string{"app", databasePath, "listAttribuitions"}
+		var dataAttribuitions _ResponseAttribuition
+		assert.NoError(t, json.Unmarshal([]byte(fakeMain()), &dataAttribuitions))
+		forest, statue := dataAttribuitions.Data[0], dataAttribuitions.Data[1]
+		assert.True(t, forest.Modified)
+		assert.Equal(t, "recoloured", forest.ModificationNotes)
+		assert.Equal(t, "“Forest” by Ana is licensed under CC BY 4.0, modified from original", forest.AttributionText.Plain)
+		assert.Equal(t, "“[url=https://example.com/forest]Forest[/url]” by Ana is licensed under [url=https://creativecommons.org/licenses/by/4.0/]CC BY 4.0[/url], modified from [url=https://example.com/forest.png]original[/url]", forest.AttributionText.BBCode)
+
+		os.Args = []string{"app", databasePath, "checkCompliance"}
+		assert.False(t, usecases.Failed([]byte(fakeMain())))
+		os.Args = []string{"app", databasePath, "addType", `{"name":""}`}
+		jsonRaw := fakeMain()
+		assert.True(t, usecases.Failed([]byte(jsonRaw)))
+		assert.Equal(t, 0, command.ExitCode(os.Args, []byte(jsonRaw)))
+
+		os.Args = []string{"app", databasePath, "updateAttribuition", `{"_id":` + strconv.FormatInt(statue.Id, 10) + `,"name":"Statue","filename":"statue.glb","type":"3D Model","author":"Bia","link":"https://example.com/statue","licence":"Attribution-NoDerivatives 4.0 International (CC BY-ND 4.0)","modified":true}`}
+		assert.Contains(t, fakeMain(), "success")
+		var report struct {
+			Data domain.ComplianceReport `json:"data"`
+		}
+		os.Args = []string{"app", databasePath, "checkCompliance"}
+		jsonRaw = fakeMain()
+		assert.True(t, usecases.Failed([]byte(jsonRaw)))
+		assert.Equal(t, 1, command.ExitCode(os.Args, []byte(jsonRaw)))
+		assert.NoError(t, json.Unmarshal([]byte(jsonRaw), &report))
+		assert.Equal(t, 1, len(report.Data.Violations))
+		assert.Equal(t, usecases.RuleNoDerivatives, report.Data.Violations[0].Rule)
+		assert.Equal(t, "statue.glb", report.Data.Violations[0].FileName)
+	})
+
+	t.Run("should migrate authors and merge them", func(t *testing.T) {
+		tempDir := t.TempDir()
+		databasePath := tempDir + "/old.db"
+		content, err := os.ReadFile("testdata/baseline.db")
+		assert.NoError(t, err)
+		assert.NoError(t, os.WriteFile(databasePath, content, 0o644))
+		db, err := sql.Open("sqlite3", databasePath)
+		assert.NoError(t, err)
+		for _, credit := range [][]string{{"Tree", "Kenney"}, {"Rock", "kenney "}, {"Bush", "kenney.nl"}} {
+			_, err := db.Exec(`INSERT INTO credits(name, filename, author, link) VALUES(?, ?, ?, 'https://kenney.nl')`,
+				credit[0], strings.ToLower(credit[0])+".png", credit[1])
+			assert.NoError(t, err)
+		}
+		assert.NoError(t, db.Close())
+
+		os.Args = []string{"app", databasePath, "listTypes"}
+		var dataTypes _ResponseType
+		assert.NoError(t, json.Unmarshal([]byte(fakeMain()), &dataTypes))
+		for _, type_ := range dataTypes.Data {
+			if type_.Name == "Font" {
+				assert.Equal(t, []string{"link", "author", "licenceText"}, type_.Requires)
+			} else {
+				assert.Equal(t, []string{"link", "author"}, type_.Requires, type_.Name)
+			}
+		}
+
+		var authors struct {
+			Data []domain.Author `json:"data"`
+		}
+		os.Args = []string{"app", databasePath, "listAuthors"}
+		assert.NoError(t, json.Unmarshal([]byte(fakeMain()), &authors))
+		assert.Equal(t, 2, len(authors.Data))
+		assert.Equal(t, "Kenney", authors.Data[0].Name)
+		assert.Equal(t, 2, authors.Data[0].Credits)
+		assert.Equal(t, "kenney.nl", authors.Data[1].Name)
+		kenney, domainName := authors.Data[0].Id, authors.Data[1].Id
+
+		os.Args = []string{"app", databasePath, "mergeAuthors", `{"into":` + strconv.FormatInt(kenney, 10) + `,"from":[` + strconv.FormatInt(domainName, 10) + `,` + strconv.FormatInt(domainName, 10) + `]}`}
+		jsonRaw := fakeMain()
+		assert.Contains(t, jsonRaw, `"moved":1`, jsonRaw)
+		os.Args = []string{"app", databasePath, "mergeAuthors", `{"into":` + strconv.FormatInt(kenney, 10) + `,"from":[999]}`}
+		assert.Contains(t, fakeMain(), "not found")
+
+		os.Args = []string{"app", databasePath, "listAuthors"}
+		assert.NoError(t, json.Unmarshal([]byte(fakeMain()), &authors))
+		assert.Equal(t, 1, len(authors.Data))
+		assert.Equal(t, []string{"kenney.nl"}, authors.Data[0].Aliases)
+		assert.Equal(t, 3, authors.Data[0].Credits)
+
+		os.Args = []string{"app", databasePath, "addAuthor", `{"name":"KENNEY"}`}
+		assert.Contains(t, fakeMain(), "author already exists")
+		os.Args = []string{"app", databasePath, "addAttribuition", `{"name":"Sign","filename":"sign.png","type":"Texture","authors":[{"name":"Kenney.nl"},{"name":"Ana"}],"link":"https://example.com/sign","licence":"MIT"}`}
+		assert.Contains(t, fakeMain(), "success")
+		os.Args = []string{"app", databasePath, "updateAuthor", `{"_id":` + strconv.FormatInt(kenney, 10) + `,"name":"Kenney Vleugels","homepage":"https://kenney.nl"}`}
+		assert.Contains(t, fakeMain(), "success")
+
+		os.Args = []string{"app", databasePath, "listAttribuitions", `{"text":"Sign"}`}
+		var dataAttribuitions _ResponseAttribuition
+		assert.NoError(t, json.Unmarshal([]byte(fakeMain()), &dataAttribuitions))
+		assert.Equal(t, 1, len(dataAttribuitions.Data))
+		sign := dataAttribuitions.Data[0]
+		assert.Equal(t, "Kenney Vleugels, Ana", sign.Author)
+		assert.Equal(t, 2, len(sign.Authors))
+		assert.Equal(t, "https://kenney.nl", sign.Authors[0].Homepage)
+
+		os.Args = []string{"app", databasePath, "exportCredits", `{"groupBy":"author"}`}
+		var document _ResponseText
+		assert.NoError(t, json.Unmarshal([]byte(fakeMain()), &document))
+		assert.True(t, strings.HasPrefix(document.Data, "Credits\n\nAna\n“Sign” by Kenney Vleugels, Ana is licensed under MIT\n\nKenney Vleugels\n“Bush”"), document.Data)
+
+		os.Args = []string{"app", databasePath, "deleteAuthor", `{"_id":` + strconv.FormatInt(sign.Authors[1].Id, 10) + `}`}
+		assert.Contains(t, fakeMain(), "success")
+		os.Args = []string{"app", databasePath, "listAttribuitions", `{"text":"Sign"}`}
+		assert.NoError(t, json.Unmarshal([]byte(fakeMain()), &dataAttribuitions))
+		assert.Equal(t, "Kenney Vleugels", dataAttribuitions.Data[0].Author)
+
+		os.Args = []string{"app", databasePath, "addAttribuition", `{"name":"Duo","filename":"duo.png","type":"Texture","authors":[{"_id":` + strconv.FormatInt(kenney, 10) + `},{"name":"Bob"}],"link":"https://example.com/duo","licence":"MIT"}`}
+		assert.Contains(t, fakeMain(), "success")
+		os.Args = []string{"app", databasePath, "addAttribuition", `{"name":"Solo","filename":"solo.png","type":"Texture","author":"Bob","link":"https://example.com/solo","licence":"MIT"}`}
+		assert.Contains(t, fakeMain(), "success")
+		os.Args = []string{"app", databasePath, "listAuthors"}
+		assert.NoError(t, json.Unmarshal([]byte(fakeMain()), &authors))
+		var bob int64
+		for _, author := range authors.Data {
+			if author.Name == "Bob" {
+				bob = author.Id
+			}
+		}
+		os.Args = []string{"app", databasePath, "mergeAuthors", `{"into":` + strconv.FormatInt(kenney, 10) + `,"from":[` + strconv.FormatInt(bob, 10) + `]}`}
+		jsonRaw = fakeMain()
+		assert.Contains(t, jsonRaw, `"moved":1`, jsonRaw)
+
+		os.Args = []string{"app", databasePath, "addAttribuition", `{"name":"Pair","filename":"pair.png","type":"Texture","authors":[{"name":"Zoe"},{"_id":` + strconv.FormatInt(kenney, 10) + `}],"link":"https://example.com/pair","licence":"MIT"}`}
+		assert.Contains(t, fakeMain(), "success")
+		os.Args = []string{"app", databasePath, "listAttribuitions", `{"text":"Pair"}`}
+		assert.NoError(t, json.Unmarshal([]byte(fakeMain()), &dataAttribuitions))
+		assert.Equal(t, "Zoe, Kenney Vleugels", dataAttribuitions.Data[0].Author)
+
+		os.Args = []string{"app", databasePath, "addAttribuition", `{"name":"Rain","type":"Music","author":"Ze","link":"http://none","licence":"MIT"}`}
+		jsonRaw = fakeMain()
+		assert.Contains(t, jsonRaw, "success", jsonRaw)
+	})
+
+	t.Run("should keep typed links", func(t *testing.T) {
+		tempDir := t.TempDir()
+		databasePath := tempDir + "/nonexistent.db"
+
+		os.Args = []string{"app", databasePath, "addAttribuition", `{"name":"Forest","filename":"forest.png","type":"Texture","author":"Ana","link":"https://example.com/forest","licence":"MIT"}`}
+		assert.Contains(t, fakeMain(), "success")
+		os.Args = []string{"app", databasePath, "listAttribuitions"}
+		var dataAttribuitions _ResponseAttribuition
+		assert.NoError(t, json.Unmarshal([]byte(fakeMain()), &dataAttribuitions))
+		credit := strconv.FormatInt(dataAttribuitions.Data[0].Id, 10)
+
+		os.Args = []string{"app", databasePath, "addLink", `{"credit":` + credit + `,"kind":"donate","url":"https://example.com/patreon","label":"Patreon"}`}
+		assert.Contains(t, fakeMain(), "success")
+		os.Args = []string{"app", databasePath, "addLink", `{"credit":` + credit + `,"kind":"proof","url":"https://example.com/receipt"}`}
+		assert.Contains(t, fakeMain(), "success")
+		os.Args = []string{"app", databasePath, "addLink", `{"credit":` + credit + `,"kind":"mirror","url":"https://example.com"}`}
+		assert.Contains(t, fakeMain(), "invalid value")
+		os.Args = []string{"app", databasePath, "addLink", `{"credit":999,"kind":"donate","url":"https://example.com"}`}
+		assert.Contains(t, fakeMain(), "not found")
+
+		os.Args = []string{"app", databasePath, "listAttribuitions"}
+		assert.NoError(t, json.Unmarshal([]byte(fakeMain()), &dataAttribuitions))
+		links := dataAttribuitions.Data[0].Links
+		assert.Equal(t, 2, len(links))
+		assert.Equal(t, domain.LinkDonate, links[0].Kind)
+		assert.Equal(t, "Patreon", links[0].Label)
+		assert.Equal(t, "https://example.com/forest", dataAttribuitions.Data[0].Link)
+
+		os.Args = []string{"app", databasePath, "exportCredits", `{"links":["donate","source"]}`}
+		var document _ResponseText
+		assert.NoError(t, json.Unmarshal([]byte(fakeMain()), &document))
+		assert.Equal(t, "Credits\n\n“Forest” by Ana is licensed under MIT · source: https://example.com/forest · Patreon: https://example.com/patreon\n", document.Data)
+		os.Args = []string{"app", databasePath, "exportNotices", `{"links":["proof"]}`}
+		assert.NoError(t, json.Unmarshal([]byte(fakeMain()), &document))
+		assert.Contains(t, document.Data, "    proof: https://example.com/receipt\n")
+		assert.NotContains(t, document.Data, "patreon")
+
+		os.Args = []string{"app", databasePath, "removeLink", `{"_id":` + strconv.FormatInt(links[0].Id, 10) + `}`}
+		assert.Contains(t, fakeMain(), "success")
+		os.Args = []string{"app", databasePath, "listAttribuitions"}
+		assert.NoError(t, json.Unmarshal([]byte(fakeMain()), &dataAttribuitions))
+		assert.Equal(t, 1, len(dataAttribuitions.Data[0].Links))
+		assert.Equal(t, domain.LinkProof, dataAttribuitions.Data[0].Links[0].Kind)
+	})
+	t.Run("should detect asset sources", func(t *testing.T) {
+		tempDir := t.TempDir()
+		databasePath := tempDir + "/nonexistent.db"
+
+		os.Args = []string{"app", databasePath, "addAttribuition", `{"name":"Ship","filename":"ship.glb","type":"3D Model","author":"Kenney","link":"https://www.kenney.nl/assets/space-kit"}`}
+		assert.Contains(t, fakeMain(), "success")
+		os.Args = []string{"app", databasePath, "addAttribuition", `{"name":"Forest","filename":"forest.png","type":"Texture","author":"Ana","link":"https://ana.itch.io/forest","licence":"MIT"}`}
+		assert.Contains(t, fakeMain(), "success")
+		os.Args = []string{"app", databasePath, "addAttribuition", `{"name":"Song","filename":"song.ogg","type":"Music","author":"Bia","link":"https://example.com/song","licence":"MIT"}`}
+		assert.Contains(t, fakeMain(), "success")
+		os.Args = []string{"app", databasePath, "addAttribuition", `{"name":"Rock","filename":"rock.png","type":"Texture","author":"Ana","link":"https://example.com/rock","licence":"MIT","source":"Nowhere"}`}
+		assert.Contains(t, fakeMain(), "invalid value")
+
+		os.Args = []string{"app", databasePath, "listAttribuitions", `{"source":"kenney"}`}
+		var dataAttribuitions _ResponseAttribuition
+		assert.NoError(t, json.Unmarshal([]byte(fakeMain()), &dataAttribuitions))
+		assert.Equal(t, 1, len(dataAttribuitions.Data))
+		assert.Equal(t, "Kenney", dataAttribuitions.Data[0].Source)
+		assert.Equal(t, "CC0 1.0 Universal (CC0 1.0) - Public Domain Dedication", dataAttribuitions.Data[0].Licence)
+		os.Args = []string{"app", databasePath, "listAttribuitions", `{"source":"itch.io"}`}
+		assert.NoError(t, json.Unmarshal([]byte(fakeMain()), &dataAttribuitions))
+		assert.Equal(t, 1, len(dataAttribuitions.Data))
+		assert.Equal(t, "Forest", dataAttribuitions.Data[0].Name)
+
+		os.Args = []string{"app", databasePath, "suggestLicence", `{"link":"https://godotengine.org/asset-library/asset/123"}`}
+		assert.Equal(t, `{"status":"success","data":{"source":"Godot Asset Library","licence":"MIT"}}`, fakeMain())
+
+		os.Args = []string{"app", databasePath, "sourceReport"}
+		var report struct {
+			Data []domain.SourceReport `json:"data"`
+		}
+		assert.NoError(t, json.Unmarshal([]byte(fakeMain()), &report))
+		counts := make(map[string]int)
+		for _, source := range report.Data {
+			counts[source.Source] = source.Credits
+		}
+		assert.Equal(t, 1, counts["Kenney"])
+		assert.Equal(t, 1, counts["itch.io"])
+		assert.Equal(t, 1, counts["Unknown"])
+		assert.Equal(t, 0, counts["Freesound"])
+	})
+	t.Run("should track purchases privately", func(t *testing.T) {
+		tempDir := t.TempDir()
+		databasePath := tempDir + "/nonexistent.db"
+
+		os.Args = []string{"app", databasePath, "addAttribuition", `{"name":"Knight","filename":"knight.glb","type":"3D Model","author":"Studio","link":"https://assetstore.unity.com/knight","licence":"Royalty Free","purchase":{"price":19.99,"currency":"usd","date":"2024-03-10","orderId":"INV-0042","seats":2,"account":"studio@example.com"}}`}
+		assert.Contains(t, fakeMain(), "success")
+		os.Args = []string{"app", databasePath, "addAttribuition", `{"name":"Castle","filename":"castle.glb","type":"3D Model","author":"Studio","link":"https://assetstore.unity.com/castle","licence":"Royalty Free","purchase":{"price":5.01,"currency":"USD","date":"2024-03-22"}}`}
+		assert.Contains(t, fakeMain(), "success")
+		os.Args = []string{"app", databasePath, "addAttribuition", `{"name":"Sword","filename":"sword.png","type":"Texture","author":"Ana","link":"https://example.com/sword","licence":"MIT"}`}
+		assert.Contains(t, fakeMain(), "success")
+		os.Args = []string{"app", databasePath, "addAttribuition", `{"name":"Shield","filename":"shield.png","type":"Texture","author":"Ana","link":"https://example.com/shield","licence":"MIT","purchase":{"price":3,"date":"2024-03-22"}}`}
+		assert.Contains(t, fakeMain(), "invalid value")
+		os.Args = []string{"app", databasePath, "addAttribuition", `{"name":"Shield","filename":"shield.png","type":"Texture","author":"Ana","link":"https://example.com/shield","licence":"MIT","purchase":{"price":3,"currency":"EUR","date":"22/03/2024"}}`}
+		assert.Contains(t, fakeMain(), "invalid value")
+
+		os.Args = []string{"app", databasePath, "listAttribuitions", `{"order":"ASC"}`}
+		var dataAttribuitions _ResponseAttribuition
+		assert.NoError(t, json.Unmarshal([]byte(fakeMain()), &dataAttribuitions))
+		knight := dataAttribuitions.Data[1]
+		assert.Equal(t, "Knight", knight.Name)
+		assert.Equal(t, &domain.Purchase{Price: 19.99, Currency: "USD", Date: "2024-03-10", OrderId: "INV-0042", Seats: 2, Account: "studio@example.com"}, knight.Purchase)
+		assert.Nil(t, dataAttribuitions.Data[2].Purchase)
+
+		os.Args = []string{"app", databasePath, "updateAttribuition", `{"_id":` + strconv.FormatInt(knight.Id, 10) + `,"name":"Knight","filename":"knight.glb","type":"3D Model","author":"Studio","link":"https://assetstore.unity.com/knight","licence":"Royalty Free"}`}
+		assert.Contains(t, fakeMain(), "success")
+		os.Args = []string{"app", databasePath, "listAttribuitions", `{"text":"Knight"}`}
+		assert.NoError(t, json.Unmarshal([]byte(fakeMain()), &dataAttribuitions))
+		assert.Equal(t, "INV-0042", dataAttribuitions.Data[0].Purchase.OrderId)
+
+		for _, export := range []string{"exportCredits", "exportNotices", "exportDep5"} {
+			os.Args = []string{"app", databasePath, export, `{}`}
+			output := fakeMain()
+			assert.Contains(t, output, "Knight")
+			assert.NotContains(t, output, "INV-0042")
+			assert.NotContains(t, output, "19.99")
+			assert.NotContains(t, output, "studio@example.com")
+		}
+
+		os.Args = []string{"app", databasePath, "purchaseReport"}
+		var report struct {
+			Data domain.PurchaseReport `json:"data"`
+		}
+		assert.NoError(t, json.Unmarshal([]byte(fakeMain()), &report))
+		assert.Equal(t, []domain.PurchaseTotal{{Key: "Unity Asset Store", Purchases: 2, Totals: map[string]float64{"USD": 25}}}, report.Data.Sources)
+		assert.Equal(t, []domain.PurchaseTotal{{Key: "Royalty Free", Purchases: 2, Totals: map[string]float64{"USD": 25}}}, report.Data.Licences)
+		assert.Equal(t, []domain.PurchaseTotal{{Key: "2024-03", Purchases: 2, Totals: map[string]float64{"USD": 25}}}, report.Data.Months)
+	})
+	t.Run("should warn about expiring licences", func(t *testing.T) {
+		tempDir := t.TempDir()
+		databasePath := tempDir + "/nonexistent.db"
+		soon := time.Now().AddDate(0, 0, 10).Format("2006-01-02")
+
+		os.Args = []string{"app", databasePath, "addAttribuition", `{"name":"Theme","filename":"theme.ogg","type":"Music","author":"Bia","link":"https://example.com/theme","licence":"MIT","validUntil":"2000-01-31"}`}
+		assert.Contains(t, fakeMain(), "success")
+		os.Args = []string{"app", databasePath, "addAttribuition", `{"name":"Title","filename":"title.ttf","type":"Font","author":"Caio","link":"https://example.com/title","licence":"MIT","validUntil":"2999-12-31","renewBy":"` + soon + `"}`}
+		assert.Contains(t, fakeMain(), "success")
+		os.Args = []string{"app", databasePath, "addAttribuition", `{"name":"Poster","filename":"poster.png","type":"Texture","author":"Ana","link":"https://example.com/poster","licence":"MIT","validUntil":"2999-12-31"}`}
+		assert.Contains(t, fakeMain(), "success")
+		os.Args = []string{"app", databasePath, "addAttribuition", `{"name":"Logo","filename":"logo.png","type":"Texture","author":"Ana","link":"https://example.com/logo","licence":"MIT","validUntil":"31/12/2999"}`}
+		assert.Contains(t, fakeMain(), "invalid value")
+
+		os.Args = []string{"app", databasePath, "listAttribuitions", `{"order":"ASC"}`}
+		var dataAttribuitions _ResponseAttribuition
+		assert.NoError(t, json.Unmarshal([]byte(fakeMain()), &dataAttribuitions))
+		assert.False(t, dataAttribuitions.Data[0].Expired)
+		assert.True(t, dataAttribuitions.Data[1].Expired)
+		assert.Equal(t, "Theme", dataAttribuitions.Data[1].Name)
+
+		os.Args = []string{"app", databasePath, "expiringLicences", `{"days":30}`}
+		var expiring struct {
+			Data []domain.ExpiringCredit `json:"data"`
+		}
+		assert.NoError(t, json.Unmarshal([]byte(fakeMain()), &expiring))
+		assert.Equal(t, 2, len(expiring.Data))
+		assert.Equal(t, "Theme", expiring.Data[0].Name)
+		assert.True(t, expiring.Data[0].Expired)
+		assert.Equal(t, "Title", expiring.Data[1].Name)
+		assert.Equal(t, 10, expiring.Data[1].DaysLeft)
+		os.Args = []string{"app", databasePath, "expiringLicences", `{"days":5}`}
+		assert.NoError(t, json.Unmarshal([]byte(fakeMain()), &expiring))
+		assert.Equal(t, 1, len(expiring.Data))
+
+		os.Args = []string{"app", databasePath, "checkCompliance"}
+		var compliance struct {
+			Status string                  `json:"status"`
+			Data   domain.ComplianceReport `json:"data"`
+		}
+		assert.NoError(t, json.Unmarshal([]byte(fakeMain()), &compliance))
+		assert.Equal(t, usecases.StatusFailure, compliance.Status)
+		assert.Equal(t, 1, len(compliance.Data.Violations))
+		assert.Equal(t, usecases.RuleExpired, compliance.Data.Violations[0].Rule)
+		assert.Equal(t, "Theme", compliance.Data.Violations[0].Name)
+	})
+	t.Run("should require permissions for all rights reserved assets", func(t *testing.T) {
+		tempDir := t.TempDir()
+		databasePath := tempDir + "/old.db"
+		content, err := os.ReadFile("testdata/baseline.db")
+		assert.NoError(t, err)
+		assert.NoError(t, os.WriteFile(databasePath, content, 0o644))
+
+		os.Args = []string{"app", databasePath, "listLicences"}
+		var dataLicences _ResponseLicence
+		assert.NoError(t, json.Unmarshal([]byte(fakeMain()), &dataLicences))
+		assertHasLicence(t, "All Rights Reserved", dataLicences.Data)
+
+		os.Args = []string{"app", databasePath, "updateProfile", `{"commercial":true}`}
+		assert.Contains(t, fakeMain(), "success")
+		os.Args = []string{"app", databasePath, "addAttribuition", `{"name":"Mascot","filename":"mascot.png","type":"Texture","author":"Dora","link":"https://example.com/mascot","licence":"All Rights Reserved"}`}
+		assert.Contains(t, fakeMain(), "success")
+		os.Args = []string{"app", databasePath, "listAttribuitions"}
+		var dataAttribuitions _ResponseAttribuition
+		assert.NoError(t, json.Unmarshal([]byte(fakeMain()), &dataAttribuitions))
+		assert.True(t, dataAttribuitions.Data[0].LicenceTerms.RequiresPermission)
+		credit := strconv.FormatInt(dataAttribuitions.Data[0].Id, 10)
+
+		var compliance struct {
+			Status string                  `json:"status"`
+			Data   domain.ComplianceReport `json:"data"`
+		}
+		os.Args = []string{"app", databasePath, "checkCompliance"}
+		assert.NoError(t, json.Unmarshal([]byte(fakeMain()), &compliance))
+		assert.Equal(t, usecases.StatusFailure, compliance.Status)
+		assert.Equal(t, usecases.RuleMissingPermission, compliance.Data.Violations[0].Rule)
+		assert.Equal(t, usecases.RuleNonCommercial, compliance.Data.Violations[1].Rule)
+
+		os.Args = []string{"app", databasePath, "addPermission", `{"credit":` + credit + `,"grantor":"Dora","grantedAt":"2024-05-02","channel":"email","scope":"this game only","text":"Sure, use it in your game.","proof":"permissions/dora.eml"}`}
+		assert.Contains(t, fakeMain(), "success")
+		os.Args = []string{"app", databasePath, "addPermission", `{"credit":` + credit + `,"grantedAt":"2024-05-02"}`}
+		assert.Contains(t, fakeMain(), "invalid value")
+		os.Args = []string{"app", databasePath, "addPermission", `{"credit":999,"grantor":"Dora"}`}
+		assert.Contains(t, fakeMain(), "not found")
+		os.Args = []string{"app", databasePath, "checkCompliance"}
+		assert.NoError(t, json.Unmarshal([]byte(fakeMain()), &compliance))
+		assert.Equal(t, 1, len(compliance.Data.Violations))
+		assert.Equal(t, usecases.RuleNonCommercial, compliance.Data.Violations[0].Rule)
+
+		os.Args = []string{"app", databasePath, "listPermissions", `{"credit":` + credit + `}`}
+		var permissions struct {
+			Data []domain.Permission `json:"data"`
+		}
+		assert.NoError(t, json.Unmarshal([]byte(fakeMain()), &permissions))
+		assert.Equal(t, 1, len(permissions.Data))
+		assert.Equal(t, "email", permissions.Data[0].Channel)
+		os.Args = []string{"app", databasePath, "updatePermission", `{"_id":` + strconv.FormatInt(permissions.Data[0].Id, 10) + `,"commercial":true}`}
+		assert.Contains(t, fakeMain(), "success")
+		os.Args = []string{"app", databasePath, "checkCompliance"}
+		assert.NoError(t, json.Unmarshal([]byte(fakeMain()), &compliance))
+		assert.Equal(t, usecases.StatusSuccess, compliance.Status)
+
+		os.Args = []string{"app", databasePath, "listAttribuitions"}
+		assert.NoError(t, json.Unmarshal([]byte(fakeMain()), &dataAttribuitions))
+		assert.Equal(t, "Dora", dataAttribuitions.Data[0].Permissions[0].Grantor)
+		assert.True(t, dataAttribuitions.Data[0].Permissions[0].Commercial)
+		assert.Equal(t, "this game only", dataAttribuitions.Data[0].Permissions[0].Scope)
+	})
+	t.Run("should keep attachments in the database", func(t *testing.T) {
+		tempDir := t.TempDir()
+		databasePath := tempDir + "/nonexistent.db"
+		receipt := filepath.Join(tempDir, "receipt.pdf")
+		content := append([]byte("%PDF-1.4 receipt "), make([]byte, 200*1024)...)
+		assert.NoError(t, os.WriteFile(receipt, content, 0o644))
+
+		os.Args = []string{"app", databasePath, "addAttribuition", `{"name":"Forest","filename":"forest.png","type":"Texture","author":"Ana","link":"https://example.com/forest","licence":"MIT"}`}
+		assert.Contains(t, fakeMain(), "success")
+		os.Args = []string{"app", databasePath, "listAttribuitions"}
+		var dataAttribuitions _ResponseAttribuition
+		assert.NoError(t, json.Unmarshal([]byte(fakeMain()), &dataAttribuitions))
+		credit := strconv.FormatInt(dataAttribuitions.Data[0].Id, 10)
+
+		os.Args = []string{"app", databasePath, "addAttachment", `{"credit":` + credit + `,"path":"` + receipt + `"}`}
+		assert.Contains(t, fakeMain(), "success")
+		os.Args = []string{"app", databasePath, "addAttachment", `{"credit":999,"path":"` + receipt + `"}`}
+		assert.Contains(t, fakeMain(), "not found")
+
+		os.Args = []string{"app", databasePath, "listAttachments", `{"credit":` + credit + `}`}
+		var attachments struct {
+			Data []domain.Attachment `json:"data"`
+		}
+		assert.NoError(t, json.Unmarshal([]byte(fakeMain()), &attachments))
+		assert.Equal(t, 1, len(attachments.Data))
+		attachment := attachments.Data[0]
+		assert.Equal(t, "receipt.pdf", attachment.Name)
+		assert.Equal(t, "application/pdf", attachment.MimeType)
+		assert.Equal(t, int64(len(content)), attachment.Size)
+		digest := sha256.Sum256(content)
+		assert.Equal(t, hex.EncodeToString(digest[:]), attachment.Sha256)
+
+		output := filepath.Join(tempDir, "out", "receipt.pdf")
+		id := strconv.FormatInt(attachment.Id, 10)
+		os.Args = []string{"app", databasePath, "getAttachment", `{"_id":` + id + `,"output":"` + output + `"}`}
+		assert.Contains(t, fakeMain(), attachment.Sha256)
+		written, err := os.ReadFile(output)
+		assert.NoError(t, err)
+		assert.Equal(t, content, written)
+
+		os.Args = []string{"app", databasePath, "deleteAttribuition", `{"_id":` + credit + `}`}
+		assert.Contains(t, fakeMain(), "success")
+		os.Args = []string{"app", databasePath, "getAttachment", `{"_id":` + id + `}`}
+		assert.Contains(t, fakeMain(), "not found")
+	})
+	t.Run("should filter and export by tags", func(t *testing.T) {
+		tempDir := t.TempDir()
+		databasePath := tempDir + "/nonexistent.db"
+
+		for _, name := range []string{"Forest", "Rock", "Sky"} {
+			os.Args = []string{"app", databasePath, "addAttribuition", `{"name":"` + name + `","filename":"` + strings.ToLower(name) + `.png","type":"Texture","author":"Ana","link":"https://example.com/` + strings.ToLower(name) + `","licence":"MIT"}`}
+			assert.Contains(t, fakeMain(), "success")
+		}
+		os.Args = []string{"app", databasePath, "listAttribuitions", `{"order":"ASC"}`}
+		var dataAttribuitions _ResponseAttribuition
+		assert.NoError(t, json.Unmarshal([]byte(fakeMain()), &dataAttribuitions))
+		forest := strconv.FormatInt(dataAttribuitions.Data[0].Id, 10)
+		rock := strconv.FormatInt(dataAttribuitions.Data[1].Id, 10)
+
+		os.Args = []string{"app", databasePath, "tag", `{"credit":` + forest + `,"tags":["Placeholder","dlc1"]}`}
+		assert.Contains(t, fakeMain(), "success")
+		os.Args = []string{"app", databasePath, "tag", `{"credit":` + rock + `,"tags":["dlc1"]}`}
+		assert.Contains(t, fakeMain(), "success")
+		os.Args = []string{"app", databasePath, "tag", `{"credit":` + rock + `,"tags":[" "]}`}
+		assert.Contains(t, fakeMain(), "invalid value")
+
+		os.Args = []string{"app", databasePath, "listAttribuitions", `{"tags":["DLC1"]}`}
+		assert.NoError(t, json.Unmarshal([]byte(fakeMain()), &dataAttribuitions))
+		assert.Equal(t, 2, len(dataAttribuitions.Data))
+		assert.Equal(t, []string{"dlc1", "Placeholder"}, dataAttribuitions.Data[0].Tags)
+		os.Args = []string{"app", databasePath, "listAttribuitions", `{"tags":["dlc1"],"excludeTags":["placeholder"]}`}
+		assert.NoError(t, json.Unmarshal([]byte(fakeMain()), &dataAttribuitions))
+		assert.Equal(t, 1, len(dataAttribuitions.Data))
+		assert.Equal(t, "Rock", dataAttribuitions.Data[0].Name)
+
+		var document _ResponseText
+		os.Args = []string{"app", databasePath, "exportCredits"}
+		assert.NoError(t, json.Unmarshal([]byte(fakeMain()), &document))
+		assert.NotContains(t, document.Data, "Forest")
+		assert.Contains(t, document.Data, "Sky")
+		os.Args = []string{"app", databasePath, "exportNotices", `{"includeTags":["dlc1"]}`}
+		assert.NoError(t, json.Unmarshal([]byte(fakeMain()), &document))
+		assert.NotContains(t, document.Data, "Forest")
+		assert.Contains(t, document.Data, "Rock")
+		assert.NotContains(t, document.Data, "Sky")
+		os.Args = []string{"app", databasePath, "exportDep5", `{"excludeTags":["marketing-only"]}`}
+		assert.NoError(t, json.Unmarshal([]byte(fakeMain()), &document))
+		assert.NotContains(t, document.Data, "forest.png")
+		assert.Contains(t, document.Data, "sky.png")
+		os.Args = []string{"app", databasePath, "exportDep5", `{"includeTags":["placeholder"]}`}
+		assert.NoError(t, json.Unmarshal([]byte(fakeMain()), &document))
+		assert.Contains(t, document.Data, "forest.png")
+
+		os.Args = []string{"app", databasePath, "untag", `{"credit":` + forest + `,"tags":["placeholder"]}`}
+		assert.Contains(t, fakeMain(), "success")
+		os.Args = []string{"app", databasePath, "listTags"}
+		var tags struct {
+			Data []domain.Tag `json:"data"`
+		}
+		assert.NoError(t, json.Unmarshal([]byte(fakeMain()), &tags))
+		assert.Equal(t, 2, len(tags.Data))
+		assert.Equal(t, "dlc1", tags.Data[0].Name)
+		assert.Equal(t, 2, tags.Data[0].Credits)
+		assert.Equal(t, 0, tags.Data[1].Credits)
+	})
+	t.Run("should validate and search custom fields", func(t *testing.T) {
+		tempDir := t.TempDir()
+		databasePath := tempDir + "/nonexistent.db"
+
+		os.Args = []string{"app", databasePath, "addField", `{"name":"assetId","type":"string","required":true}`}
+		assert.Contains(t, fakeMain(), "success")
+		os.Args = []string{"app", databasePath, "addField", `{"name":"region","type":"enum","options":["EU","US"]}`}
+		assert.Contains(t, fakeMain(), "success")
+		os.Args = []string{"app", databasePath, "addField", `{"name":"cost","type":"number"}`}
+		assert.Contains(t, fakeMain(), "success")
+		os.Args = []string{"app", databasePath, "addField", `{"name":"platform","type":"enum"}`}
+		assert.Contains(t, fakeMain(), "invalid value")
+		os.Args = []string{"app", databasePath, "addField", `{"name":"weird","type":"color"}`}
+		assert.Contains(t, fakeMain(), "invalid value")
+
+		add := func(name string, fields string) string {
+			os.Args = []string{"app", databasePath, "addAttribuition", `{"name":"` + name + `","filename":"` + strings.ToLower(name) + `.png","type":"Texture","author":"Ana","link":"https://example.com/` + strings.ToLower(name) + `","licence":"MIT","attributionOverride":"{title} ({field:assetId})","fields":` + fields + `}`}
+			return fakeMain()
+		}
+		assert.Contains(t, add("Forest", `{"assetId":"TX-001","Region":"EU","cost":2.5}`), "success")
+		assert.Contains(t, add("Rock", `{"assetId":"TX-002","region":"US"}`), "success")
+		assert.Contains(t, add("Sky", `{"region":"EU"}`), "invalid value")
+		assert.Contains(t, add("Sky", `{"assetId":"TX-003","region":"BR"}`), "invalid value")
+		assert.Contains(t, add("Sky", `{"assetId":"TX-003","cost":"free"}`), "invalid value")
+		assert.Contains(t, add("Sky", `{"assetId":"TX-003","artist":"Bia"}`), "invalid value")
+
+		os.Args = []string{"app", databasePath, "listAttribuitions", `{"fields":{"region":"eu"}}`}
+		var dataAttribuitions _ResponseAttribuition
+		assert.NoError(t, json.Unmarshal([]byte(fakeMain()), &dataAttribuitions))
+		assert.Equal(t, 1, len(dataAttribuitions.Data))
+		forest := dataAttribuitions.Data[0]
+		assert.Equal(t, map[string]interface{}{"assetId": "TX-001", "region": "EU", "cost": 2.5}, forest.Fields)
+		assert.Equal(t, "Forest (TX-001)", forest.AttributionText.Plain)
+		os.Args = []string{"app", databasePath, "listAttribuitions", `{"text":"TX-002"}`}
+		assert.NoError(t, json.Unmarshal([]byte(fakeMain()), &dataAttribuitions))
+		assert.Equal(t, 1, len(dataAttribuitions.Data))
+		assert.Equal(t, "Rock", dataAttribuitions.Data[0].Name)
+
+		os.Args = []string{"app", databasePath, "updateAttribuition", `{"_id":` + strconv.FormatInt(forest.Id, 10) + `,"name":"Forest","filename":"forest.png","type":"Texture","author":"Ana","link":"https://example.com/forest","licence":"MIT","fields":{"cost":null}}`}
+		assert.Contains(t, fakeMain(), "success")
+		// fresh responses, decoding over an old one merges the fields maps
+		os.Args = []string{"app", databasePath, "listAttribuitions", `{"text":"Forest"}`}
+		var updated _ResponseAttribuition
+		assert.NoError(t, json.Unmarshal([]byte(fakeMain()), &updated))
+		assert.Equal(t, map[string]interface{}{"assetId": "TX-001", "region": "EU"}, updated.Data[0].Fields)
+
+		os.Args = []string{"app", databasePath, "listFields"}
+		var fields struct {
+			Data []domain.FieldDefinition `json:"data"`
+		}
+		assert.NoError(t, json.Unmarshal([]byte(fakeMain()), &fields))
+		assert.Equal(t, 3, len(fields.Data))
+		assert.Equal(t, "assetId", fields.Data[0].Name)
+		os.Args = []string{"app", databasePath, "updateField", `{"_id":` + strconv.FormatInt(fields.Data[0].Id, 10) + `,"type":"number"}`}
+		assert.Contains(t, fakeMain(), "invalid value")
+		os.Args = []string{"app", databasePath, "deleteField", `{"_id":` + strconv.FormatInt(fields.Data[2].Id, 10) + `}`}
+		assert.Contains(t, fakeMain(), "success")
+		os.Args = []string{"app", databasePath, "listAttribuitions", `{"text":"Forest"}`}
+		var deleted _ResponseAttribuition
+		assert.NoError(t, json.Unmarshal([]byte(fakeMain()), &deleted))
+		assert.Equal(t, map[string]interface{}{"assetId": "TX-001"}, deleted.Data[0].Fields)
+	})
+	t.Run("should nest types", func(t *testing.T) {
+		tempDir := t.TempDir()
+		databasePath := tempDir + "/nonexistent.db"
+
+		listTypes := func() map[string]string {
+			os.Args = []string{"app", databasePath, "listTypes"}
+			var dataTypes _ResponseType
+			assert.NoError(t, json.Unmarshal([]byte(fakeMain()), &dataTypes))
+			ids := make(map[string]string)
+			for _, type_ := range dataTypes.Data {
+				ids[type_.Name] = strconv.FormatInt(type_.Id, 10)
+			}
+			return ids
+		}
+		os.Args = []string{"app", databasePath, "addType", `{"name":"Audio"}`}
+		assert.Contains(t, fakeMain(), "success")
+		ids := listTypes()
+		os.Args = []string{"app", databasePath, "updateType", `{"_id":` + ids["Music"] + `,"parent":` + ids["Audio"] + `}`}
+		assert.Contains(t, fakeMain(), "success")
+		os.Args = []string{"app", databasePath, "addType", `{"name":"Ambient","parent":` + ids["Music"] + `}`}
+		assert.Contains(t, fakeMain(), "success")
+		os.Args = []string{"app", databasePath, "addType", `{"name":"Lost","parent":9999}`}
+		assert.Contains(t, fakeMain(), "not found")
+		ids = listTypes()
+		os.Args = []string{"app", databasePath, "updateType", `{"_id":` + ids["Audio"] + `,"parent":` + ids["Ambient"] + `}`}
+		assert.Contains(t, fakeMain(), "invalid value")
+
+		os.Args = []string{"app", databasePath, "listTypes", `{"tree":true}`}
+		var tree _ResponseType
+		assert.NoError(t, json.Unmarshal([]byte(fakeMain()), &tree))
+		assert.Equal(t, "Audio", tree.Data[1].Name)
+		assert.Equal(t, "Music", tree.Data[1].Children[0].Name)
+		assert.Equal(t, "Ambient", tree.Data[1].Children[0].Children[0].Name)
+
+		for name, type_ := range map[string]string{"Rain": "Ambient", "Theme": "Music", "Boom": "Sound Effect"} {
+			os.Args = []string{"app", databasePath, "addAttribuition", `{"name":"` + name + `","filename":"` + strings.ToLower(name) + `.ogg","type":"` + type_ + `","author":"Ana","link":"https://example.com/` + strings.ToLower(name) + `","licence":"MIT"}`}
+			assert.Contains(t, fakeMain(), "success")
+		}
+		os.Args = []string{"app", databasePath, "listAttribuitions", `{"type":"audio"}`}
+		var dataAttribuitions _ResponseAttribuition
+		assert.NoError(t, json.Unmarshal([]byte(fakeMain()), &dataAttribuitions))
+		assert.Equal(t, 2, len(dataAttribuitions.Data))
+
+		var document _ResponseText
+		os.Args = []string{"app", databasePath, "exportCredits", `{"format":"markdown","groupBy":"type"}`}
+		assert.NoError(t, json.Unmarshal([]byte(fakeMain()), &document))
+		assert.Contains(t, document.Data, "## Audio\n\n### Music\n\n- “[Theme]")
+		assert.Contains(t, document.Data, "#### Ambient\n\n- “[Rain]")
+		assert.Contains(t, document.Data, "## Sound Effect\n\n- “[Boom]")
+		assert.NotContains(t, document.Data, "Texture")
+
+		os.Args = []string{"app", databasePath, "deleteType", `{"_id":` + ids["Music"] + `}`}
+		assert.Contains(t, fakeMain(), "success")
+		os.Args = []string{"app", databasePath, "listAttribuitions", `{"text":"Theme"}`}
+		assert.NoError(t, json.Unmarshal([]byte(fakeMain()), &dataAttribuitions))
+		assert.Equal(t, "Audio", dataAttribuitions.Data[0].Type)
+		os.Args = []string{"app", databasePath, "listTypes", `{"tree":true}`}
+		var moved _ResponseType
+		assert.NoError(t, json.Unmarshal([]byte(fakeMain()), &moved))
+		assert.Equal(t, "Ambient", moved.Data[1].Children[0].Name)
+	})
+	t.Run("should follow the rules of the types", func(t *testing.T) {
+		tempDir := t.TempDir()
+		databasePath := tempDir + "/nonexistent.db"
+
+		os.Args = []string{"app", databasePath, "listTypes"}
+		var dataTypes _ResponseType
+		assert.NoError(t, json.Unmarshal([]byte(fakeMain()), &dataTypes))
+		ids := make(map[string]domain.Type)
+		for _, type_ := range dataTypes.Data {
+			ids[type_.Name] = type_
+		}
+		assert.Equal(t, "MIT", ids["Code Snippet"].DefaultLicence)
+		assert.Equal(t, []string{"link", "author", "licenceText"}, ids["Font"].Requires)
+
+		os.Args = []string{"app", databasePath, "addAttribuition", `{"name":"Easing","filename":"easing.gd","type":"code snippet","author":"Ana","link":"https://example.com/easing"}`}
+		assert.Contains(t, fakeMain(), "success")
+		os.Args = []string{"app", databasePath, "addAttribuition", `{"name":"Title","filename":"title.ttf","type":"Font","author":"Ana","link":"https://example.com/title","licence":"Royalty Free"}`}
+		assert.Contains(t, fakeMain(), "invalid value")
+		os.Args = []string{"app", databasePath, "addAttribuition", `{"name":"Title","filename":"title.ttf","type":"Font","author":"Ana","link":"https://example.com/title","licence":"MIT"}`}
+		assert.Contains(t, fakeMain(), "success")
+		os.Args = []string{"app", databasePath, "addAttribuition", `{"name":"Beach","filename":"beach.jpg","type":"Photo","link":"https://example.com/beach","licence":"MIT"}`}
+		assert.Contains(t, fakeMain(), "invalid value")
+
+		os.Args = []string{"app", databasePath, "addType", `{"name":"Meme","requires":["colour"]}`}
+		assert.Contains(t, fakeMain(), "invalid value")
+		os.Args = []string{"app", databasePath, "addType", `{"name":"Meme","defaultLicence":"Nope"}`}
+		assert.Contains(t, fakeMain(), "invalid value")
+		os.Args = []string{"app", databasePath, "addType", `{"name":"Meme","requires":[],"defaultLicence":"MIT"}`}
+		assert.Contains(t, fakeMain(), "success")
+		os.Args = []string{"app", databasePath, "addAttribuition", `{"name":"Doge","type":"Meme"}`}
+		assert.Contains(t, fakeMain(), "success")
+
+		os.Args = []string{"app", databasePath, "updateType", `{"_id":` + strconv.FormatInt(ids["Photo"].Id, 10) + `,"requires":["author","filename"]}`}
+		assert.Contains(t, fakeMain(), "success")
+		os.Args = []string{"app", databasePath, "addAttribuition", `{"name":"Beach","type":"Photo","author":"Ana","licence":"MIT"}`}
+		assert.Contains(t, fakeMain(), "invalid value")
+		os.Args = []string{"app", databasePath, "addAttribuition", `{"name":"Beach","filename":"beach.jpg","type":"Photo","author":"Ana","licence":"MIT"}`}
+		assert.Contains(t, fakeMain(), "success")
+
+		os.Args = []string{"app", databasePath, "listAttribuitions", `{"text":"Easing"}`}
+		var dataAttribuitions _ResponseAttribuition
+		assert.NoError(t, json.Unmarshal([]byte(fakeMain()), &dataAttribuitions))
+		assert.Equal(t, "Code Snippet", dataAttribuitions.Data[0].Type)
+		assert.Equal(t, "MIT", dataAttribuitions.Data[0].Licence)
+	})
+	t.Run("should start databases from seed packs", func(t *testing.T) {
+		tempDir := t.TempDir()
+		databasePath := tempDir + "/nonexistent.db"
+		studioPath := tempDir + "/studio-defaults.json"
+		assert.NoError(t, os.WriteFile(studioPath, []byte(`{
+			"name": "studio",
+			"licences": [
+				{"spdx": "MIT", "name": "MIT"},
+				{"name": "Studio Licence", "link": "https://example.com/licence", "requiresAttribution": false}
+			],
+			"types": [
+				{"name": "Ambient", "parent": "Audio", "defaultLicence": "Studio Licence"},
+				{"name": "Audio", "requires": []}
+			],
+			"sources": [{"name": "Studio Assets", "baseUrl": "https://assets.example.com", "defaultLicence": "Studio Licence"}]
+		}`), 0644))
+		yamlPath := tempDir + "/fields.yaml"
+		assert.NoError(t, os.WriteFile(yamlPath, []byte("fields:\n  - name: assetId\n    type: string\n"), 0644))
+		cyclePath := tempDir + "/cycle.json"
+		assert.NoError(t, os.WriteFile(cyclePath, []byte(`{"types":[{"name":"A","parent":"B"},{"name":"B","parent":"A"}]}`), 0644))
+
+		os.Args = []string{"app", databasePath, "init", "--seed", cyclePath}
+		assert.Contains(t, fakeMain(), "invalid value")
+		unknownPath := tempDir + "/unknown.json"
+		assert.NoError(t, os.WriteFile(unknownPath, []byte(`{"types":[{"name":"A","defaultLicence":"Missing"}]}`), 0644))
+		os.Args = []string{"app", databasePath, "init", "--seed", unknownPath}
+		assert.Contains(t, fakeMain(), "unknown licence")
+		_, err := os.Stat(databasePath)
+		assert.True(t, os.IsNotExist(err))
+		os.Args = []string{"app", databasePath, "init", "--seed", "licences", "--seed", studioPath}
+		assert.Contains(t, fakeMain(), "success")
+		os.Args = []string{"app", databasePath, "init", "--seed", studioPath}
+		assert.Contains(t, fakeMain(), "already exists")
+
+		os.Args = []string{"app", databasePath, "listTypes"}
+		var dataTypes _ResponseType
+		assert.NoError(t, json.Unmarshal([]byte(fakeMain()), &dataTypes))
+		assert.Equal(t, 2, len(dataTypes.Data))
+		assert.Equal(t, "Ambient", dataTypes.Data[0].Name)
+		assert.Equal(t, dataTypes.Data[1].Id, dataTypes.Data[0].Parent)
+		assert.Equal(t, "Studio Licence", dataTypes.Data[0].DefaultLicence)
+		assert.Equal(t, []string{}, dataTypes.Data[1].Requires)
+		os.Args = []string{"app", databasePath, "listLicences"}
+		var dataLicences _ResponseLicence
+		assert.NoError(t, json.Unmarshal([]byte(fakeMain()), &dataLicences))
+		assert.Equal(t, 24, len(dataLicences.Data))
+		os.Args = []string{"app", databasePath, "addAttribuition", `{"name":"Rain","type":"Ambient","author":"Ana","link":"https://assets.example.com/rain"}`}
+		assert.Contains(t, fakeMain(), "success")
+
+		var report struct {
+			Data domain.SeedReport `json:"data"`
+		}
+		os.Args = []string{"app", databasePath, "seed", `{"pack":"types"}`}
+		assert.NoError(t, json.Unmarshal([]byte(fakeMain()), &report))
+		assert.Equal(t, domain.SeedReport{Types: 11}, report.Data)
+		os.Args = []string{"app", databasePath, "seed", `{"pack":"types"}`}
+		report.Data = domain.SeedReport{}
+		assert.NoError(t, json.Unmarshal([]byte(fakeMain()), &report))
+		assert.Equal(t, domain.SeedReport{Skipped: 11}, report.Data)
+		os.Args = []string{"app", databasePath, "seed", `{"pack":"` + yamlPath + `"}`}
+		assert.NoError(t, json.Unmarshal([]byte(fakeMain()), &report))
+		assert.Equal(t, 1, report.Data.Fields)
+		os.Args = []string{"app", databasePath, "seed", `{"pack":"missing-pack"}`}
+		assert.Contains(t, fakeMain(), "cant read seed pack")
+
+		os.Args = []string{"app", databasePath, "listSeeds"}
+		assert.Contains(t, fakeMain(), "types-pt-BR")
+
+		orphanPath := tempDir + "/orphan.json"
+		assert.NoError(t, os.WriteFile(orphanPath, []byte(`{"types":[{"name":"A","parent":"Missing"}]}`), 0644))
+		os.Args = []string{"app", databasePath, "seed", `{"pack":"` + orphanPath + `"}`}
+		jsonRaw := fakeMain()
+		var failure _ResponseText
+		assert.NoError(t, json.Unmarshal([]byte(jsonRaw), &failure))
+		assert.Contains(t, *failure.Message, `unknown parent type "Missing"`)
+		assert.True(t, usecases.Failed([]byte(jsonRaw)))
+	})
+
+	t.Run("should run batches in one transaction", func(t *testing.T) {
+		tempDir := t.TempDir()
+		databasePath := tempDir + "/nonexistent.db"
+
+		os.Args = []string{"app", databasePath, "batch", `[
+			{"command":"addType","payload":{"name":"Audio","requires":[]},"as":"audio"},
+			{"command":"addType","payload":{"name":"Ambient","parent":"$audio"}},
+			{"command":"addAttribuition","payload":{"name":"Rain","type":"Ambient","author":"Ana","link":"https://example.com/rain","licence":"MIT"},"as":"rain"},
+			{"command":"addLink","payload":{"credit":"$rain","kind":"donate","url":"https://example.com/tip"}},
+			{"command":"listTypes"}
+		]`}
+		var results struct {
+			Status string            `json:"status"`
+			Data   []json.RawMessage `json:"data"`
+		}
+		assert.NoError(t, json.Unmarshal([]byte(fakeMain()), &results))
+		assert.Equal(t, "success", results.Status)
+		assert.Equal(t, 5, len(results.Data))
+		assert.Contains(t, string(results.Data[1]), `"_id"`)
+		assert.Contains(t, string(results.Data[4]), "Ambient")
+
+		os.Args = []string{"app", databasePath, "listTypes", `{"tree":true}`}
+		var dataTypes _ResponseType
+		assert.NoError(t, json.Unmarshal([]byte(fakeMain()), &dataTypes))
+		types := len(dataTypes.Data)
+		os.Args = []string{"app", databasePath, "listAttribuitions", `{"text":"Rain"}`}
+		var dataAttribuitions _ResponseAttribuition
+		assert.NoError(t, json.Unmarshal([]byte(fakeMain()), &dataAttribuitions))
+		assert.Equal(t, 1, len(dataAttribuitions.Data))
+		assert.Equal(t, 1, len(dataAttribuitions.Data[0].Links))
+
+		os.Args = []string{"app", databasePath, "batch", `[
+			{"command":"addType","payload":{"name":"Music"}},
+			{"command":"addAttribuition","payload":{"name":"Song","type":"Music","author":"Ana","link":"https://example.com/song","licence":"MIT"}},
+			{"command":"addLink","payload":{"credit":"$1","kind":"mirror","url":"https://example.com"}}
+		]`}
+		response := fakeMain()
+		assert.Contains(t, response, "batch rolled back: operation 2 (addLink) failed")
+		os.Args = []string{"app", databasePath, "listTypes", `{"tree":true}`}
+		assert.NoError(t, json.Unmarshal([]byte(fakeMain()), &dataTypes))
+		assert.Equal(t, types, len(dataTypes.Data))
+		os.Args = []string{"app", databasePath, "listAttribuitions", `{"text":"Song"}`}
+		dataAttribuitions.Data = nil
+		assert.NoError(t, json.Unmarshal([]byte(fakeMain()), &dataAttribuitions))
+		assert.Equal(t, 0, len(dataAttribuitions.Data))
+
+		leakyPath := tempDir + "/leaky.json"
+		assert.NoError(t, os.WriteFile(leakyPath, []byte(`{
+			"licences": [{"name": "Leaky Licence", "link": "https://example.com/leaky"}],
+			"types": [{"name": "Leaky", "defaultLicence": "Missing Licence"}]
+		}`), 0644))
+		os.Args = []string{"app", databasePath, "batch", `[
+			{"command":"addType","payload":{"name":"BeforeFail"}},
+			{"command":"seed","payload":{"pack":"` + leakyPath + `"}},
+			{"command":"addType","payload":{"name":"AfterFail"}}
+		]`}
+		response = fakeMain()
+		assert.Contains(t, response, "batch rolled back: operation 1 (seed) failed")
+		assert.Contains(t, response, "Missing Licence")
+		os.Args = []string{"app", databasePath, "listTypes", `{"tree":true}`}
+		assert.NoError(t, json.Unmarshal([]byte(fakeMain()), &dataTypes))
+		assert.Equal(t, types, len(dataTypes.Data))
+		os.Args = []string{"app", databasePath, "listLicences"}
+		assert.NotContains(t, fakeMain(), "Leaky Licence")
+
+		os.Args = []string{"app", databasePath, "batch", `[{"command":"init"}]`}
+		assert.Contains(t, fakeMain(), "invalid command")
+		os.Args = []string{"app", databasePath, "batch", `[]`}
+		assert.Contains(t, fakeMain(), "invalid value")
+	})
+
+	t.Run("should upsert attribuitions by their keys", func(t *testing.T) {
+		tempDir := t.TempDir()
+		databasePath := tempDir + "/nonexistent.db"
+
+		var upserted struct {
+			Data usecases.Upserted `json:"data"`
+		}
+		os.Args = []string{"app", databasePath, "upsertAttribuition", `{"name":"Rain","filename":"rain.ogg","type":"Music","author":"Ana","link":"https://example.com/rain","licence":"MIT"}`}
+		assert.NoError(t, json.Unmarshal([]byte(fakeMain()), &upserted))
+		assert.Equal(t, usecases.UpsertCreated, upserted.Data.Result)
+		id := upserted.Data.Id
+		os.Args = []string{"app", databasePath, "upsertAttribuition", `{"name":"Rain","filename":"rain.ogg","type":"Music","author":"Ana","link":"https://example.com/rain","licence":"MIT"}`}
+		assert.NoError(t, json.Unmarshal([]byte(fakeMain()), &upserted))
+		assert.Equal(t, usecases.Upserted{Id: id, Result: usecases.UpsertUnchanged}, upserted.Data)
+		os.Args = []string{"app", databasePath, "upsertAttribuition", `{"name":"Heavy Rain","filename":"rain.ogg","author":"Ana"}`}
+		assert.NoError(t, json.Unmarshal([]byte(fakeMain()), &upserted))
+		assert.Equal(t, usecases.Upserted{Id: id, Result: usecases.UpsertUpdated}, upserted.Data)
+
+		os.Args = []string{"app", databasePath, "upsertAttribuition", `{"key":"externalId","externalId":"sfx-12","name":"Wind","type":"Music","author":"Bia","link":"https://example.com/wind","licence":"MIT"}`}
+		assert.NoError(t, json.Unmarshal([]byte(fakeMain()), &upserted))
+		assert.Equal(t, usecases.UpsertCreated, upserted.Data.Result)
+		os.Args = []string{"app", databasePath, "upsertAttribuition", `{"key":"externalId","externalId":"sfx-12","filename":"wind.ogg"}`}
+		assert.NoError(t, json.Unmarshal([]byte(fakeMain()), &upserted))
+		assert.Equal(t, usecases.UpsertUpdated, upserted.Data.Result)
+		os.Args = []string{"app", databasePath, "upsertAttribuition", `{"key":"link","name":"Wind","link":"https://example.com/wind","author":"Bia"}`}
+		assert.NoError(t, json.Unmarshal([]byte(fakeMain()), &upserted))
+		assert.Equal(t, usecases.UpsertUnchanged, upserted.Data.Result)
+
+		os.Args = []string{"app", databasePath, "upsertAttribuition", `{"key":"externalId","name":"Fire"}`}
+		assert.Contains(t, fakeMain(), "invalid value")
+		os.Args = []string{"app", databasePath, "upsertAttribuition", `{"key":"colour","name":"Fire"}`}
+		assert.Contains(t, fakeMain(), "invalid value")
+
+		os.Args = []string{"app", databasePath, "listAttribuitions"}
+		var dataAttribuitions _ResponseAttribuition
+		assert.NoError(t, json.Unmarshal([]byte(fakeMain()), &dataAttribuitions))
+		assert.Equal(t, 2, len(dataAttribuitions.Data))
+		assert.Equal(t, "Heavy Rain", dataAttribuitions.Data[0].Name)
+		assert.Equal(t, "sfx-12", dataAttribuitions.Data[1].ExternalId)
+		assert.Equal(t, "wind.ogg", dataAttribuitions.Data[1].FileName)
+	})
+
+	t.Run("should bulk update attribuitions", func(t *testing.T) {
+		tempDir := t.TempDir()
+		databasePath := tempDir + "/nonexistent.db"
+
+		for _, name := range []string{"Rain", "Wind", "Fire"} {
+			author := "Ana"
+			if name == "Fire" {
+				author = "Bia"
+			}
+			os.Args = []string{"app", databasePath, "addAttribuition", `{"name":"` + name + `","type":"Music","author":"` + author + `","link":"https://example.com/` + name + `","licence":"MIT"}`}
+			assert.Contains(t, fakeMain(), "success")
+		}
+		os.Args = []string{"app", databasePath, "updateAttribuition", `{"_id":2,"licence":"CC0 1.0 Universal (CC0 1.0) - Public Domain Dedication"}`}
+		assert.Contains(t, fakeMain(), "success")
+
+		var report struct {
+			Data struct {
+				Matched       int                   `json:"matched"`
+				Changed       int                   `json:"changed"`
+				DryRun        bool                  `json:"dryRun"`
+				Attribuitions []domain.Attribuition `json:"attribuitions"`
+			} `json:"data"`
+		}
+		os.Args = []string{"app", databasePath, "bulkUpdateAttribuitions", `{"query":{"text":"Ana"},"changes":{"licence":"CC0 1.0 Universal (CC0 1.0) - Public Domain Dedication"},"dryRun":true}`}
+		assert.NoError(t, json.Unmarshal([]byte(fakeMain()), &report))
+		assert.Equal(t, 2, report.Data.Matched)
+		assert.Equal(t, 1, report.Data.Changed)
+		assert.Equal(t, "Rain", report.Data.Attribuitions[0].Name)
+		assert.True(t, report.Data.DryRun)
+		os.Args = []string{"app", databasePath, "listAttribuitions", `{"text":"Rain"}`}
+		var dataAttribuitions _ResponseAttribuition
+		assert.NoError(t, json.Unmarshal([]byte(fakeMain()), &dataAttribuitions))
+		assert.Equal(t, "MIT", dataAttribuitions.Data[0].Licence)
+
+		os.Args = []string{"app", databasePath, "bulkUpdateAttribuitions", `{"query":{"text":"Ana"},"changes":{"licence":"CC0 1.0 Universal (CC0 1.0) - Public Domain Dedication"}}`}
+		assert.NoError(t, json.Unmarshal([]byte(fakeMain()), &report))
+		assert.False(t, report.Data.DryRun)
+		os.Args = []string{"app", databasePath, "listAttribuitions"}
+		assert.NoError(t, json.Unmarshal([]byte(fakeMain()), &dataAttribuitions))
+		for _, attribuition := range dataAttribuitions.Data {
+			if attribuition.Author == "Ana" {
+				assert.Equal(t, "CC0 1.0 Universal (CC0 1.0) - Public Domain Dedication", attribuition.Licence)
+			} else {
+				assert.Equal(t, "MIT", attribuition.Licence)
+			}
+		}
+
+		os.Args = []string{"app", databasePath, "bulkUpdateAttribuitions", `{"query":{"text":"Fire"},"changes":{"author":"Carla"},"dryRun":true}`}
+		assert.NoError(t, json.Unmarshal([]byte(fakeMain()), &report))
+		assert.Equal(t, "Carla", report.Data.Attribuitions[0].Authors[0].Name)
+		os.Args = []string{"app", databasePath, "bulkUpdateAttribuitions", `{"query":{"text":"Fire"},"changes":{"author":"Ana"}}`}
+		assert.NoError(t, json.Unmarshal([]byte(fakeMain()), &report))
+		assert.Equal(t, 1, len(report.Data.Attribuitions[0].Authors))
+		assert.NotZero(t, report.Data.Attribuitions[0].Authors[0].Id)
+		assert.Equal(t, "Ana", report.Data.Attribuitions[0].Authors[0].Name)
+
+		os.Args = []string{"app", databasePath, "bulkUpdateAttribuitions", `{"query":{},"changes":{"type":"Photo","link":""}}`}
+		assert.Contains(t, fakeMain(), "invalid value")
+		os.Args = []string{"app", databasePath, "listAttribuitions", `{"type":"Music"}`}
+		assert.NoError(t, json.Unmarshal([]byte(fakeMain()), &dataAttribuitions))
+		assert.Equal(t, 3, len(dataAttribuitions.Data))
+		os.Args = []string{"app", databasePath, "bulkUpdateAttribuitions", `{"query":{},"changes":{"type":"Photo"}}`}
+		assert.NoError(t, json.Unmarshal([]byte(fakeMain()), &report))
+		assert.Equal(t, 3, report.Data.Changed)
+		os.Args = []string{"app", databasePath, "bulkUpdateAttribuitions", `{"query":{"type":"Photo"},"changes":{"_id":1}}`}
+		assert.Contains(t, fakeMain(), "invalid value")
+		os.Args = []string{"app", databasePath, "bulkUpdateAttribuitions", `{"changes":{"type":"Photo"}}`}
+		assert.Contains(t, fakeMain(), "invalid value")
+	})
+
+	t.Run("should merge licences and types", func(t *testing.T) {
+		tempDir := t.TempDir()
+		databasePath := tempDir + "/nonexistent.db"
+
+		var created struct {
+			Data usecases.Created `json:"data"`
+		}
+		os.Args = []string{"app", databasePath, "addLicence", `{"name":"CC-BY 4.0","link":"https://creativecommons.org/licenses/by/4.0/","text":"Attribution 4.0 International"}`}
+		assert.NoError(t, json.Unmarshal([]byte(fakeMain()), &created))
+		duplicated := created.Data.Id
+		os.Args = []string{"app", databasePath, "addLicence", `{"name":"CC BY 4.0","link":"https://creativecommons.org/licenses/by/4.0/"}`}
+		assert.NoError(t, json.Unmarshal([]byte(fakeMain()), &created))
+		other := created.Data.Id
+		os.Args = []string{"app", databasePath, "addType", `{"name":"Sounds","defaultLicence":"CC BY 4.0"}`}
+		assert.NoError(t, json.Unmarshal([]byte(fakeMain()), &created))
+		sounds := created.Data.Id
+		os.Args = []string{"app", databasePath, "addType", `{"name":"Ambient","parent":` + strconv.FormatInt(sounds, 10) + `}`}
+		assert.NoError(t, json.Unmarshal([]byte(fakeMain()), &created))
+		ambient := created.Data.Id
+		os.Args = []string{"app", databasePath, "addType", `{"name":"Audio"}`}
+		assert.NoError(t, json.Unmarshal([]byte(fakeMain()), &created))
+		audio := created.Data.Id
+		os.Args = []string{"app", databasePath, "addAttribuition", `{"name":"Rain","type":"Sounds","author":"Ana","link":"https://example.com/rain","licence":"CC-BY 4.0"}`}
+		assert.Contains(t, fakeMain(), "success")
+		os.Args = []string{"app", databasePath, "addAttribuition", `{"name":"Wind","type":"Music","author":"Ana","link":"https://example.com/wind","licence":"CC BY 4.0"}`}
+		assert.Contains(t, fakeMain(), "success")
+
+		os.Args = []string{"app", databasePath, "listLicences"}
+		var dataLicences _ResponseLicence
+		assert.NoError(t, json.Unmarshal([]byte(fakeMain()), &dataLicences))
+		licences := len(dataLicences.Data)
+		var into int64
+		for _, licence := range dataLicences.Data {
+			if licence.SpdxId == "CC-BY-4.0" {
+				into = licence.Id
+			}
+		}
+		assert.NotZero(t, into)
+		var report struct {
+			Data struct {
+				Moved int64 `json:"moved"`
+			} `json:"data"`
+		}
+		os.Args = []string{"app", databasePath, "mergeLicences", `{"into":` + strconv.FormatInt(into, 10) + `,"from":[` + strconv.FormatInt(duplicated, 10) + `,` + strconv.FormatInt(other, 10) + `,` + strconv.FormatInt(other, 10) + `]}`}
+		assert.NoError(t, json.Unmarshal([]byte(fakeMain()), &report))
+		assert.Equal(t, int64(2), report.Data.Moved)
+		os.Args = []string{"app", databasePath, "listLicences"}
+		assert.NoError(t, json.Unmarshal([]byte(fakeMain()), &dataLicences))
+		assert.Equal(t, licences-2, len(dataLicences.Data))
+		os.Args = []string{"app", databasePath, "mergeLicences", `{"into":` + strconv.FormatInt(into, 10) + `,"from":[` + strconv.FormatInt(other, 10) + `]}`}
+		assert.Contains(t, fakeMain(), "not found")
+
+		os.Args = []string{"app", databasePath, "mergeTypes", `{"into":` + strconv.FormatInt(ambient, 10) + `,"from":[` + strconv.FormatInt(sounds, 10) + `]}`}
+		assert.Contains(t, fakeMain(), "invalid value")
+		os.Args = []string{"app", databasePath, "addType", `{"name":"Strict","requires":["filename"]}`}
+		assert.NoError(t, json.Unmarshal([]byte(fakeMain()), &created))
+		os.Args = []string{"app", databasePath, "mergeTypes", `{"into":` + strconv.FormatInt(created.Data.Id, 10) + `,"from":[` + strconv.FormatInt(sounds, 10) + `]}`}
+		assert.Contains(t, fakeMain(), "breaks the rules of the type: invalid value")
+		os.Args = []string{"app", databasePath, "mergeTypes", `{"into":` + strconv.FormatInt(audio, 10) + `,"from":[` + strconv.FormatInt(sounds, 10) + `,` + strconv.FormatInt(sounds, 10) + `]}`}
+		assert.NoError(t, json.Unmarshal([]byte(fakeMain()), &report))
+		assert.Equal(t, int64(1), report.Data.Moved)
+		os.Args = []string{"app", databasePath, "listTypes"}
+		var dataTypes _ResponseType
+		assert.NoError(t, json.Unmarshal([]byte(fakeMain()), &dataTypes))
+		for _, type_ := range dataTypes.Data {
+			assert.NotEqual(t, "Sounds", type_.Name)
+			if type_.Id == ambient {
+				assert.Equal(t, audio, type_.Parent)
+			}
+		}
+
+		os.Args = []string{"app", databasePath, "listAttribuitions", `{"type":"Audio"}`}
+		var dataAttribuitions _ResponseAttribuition
+		assert.NoError(t, json.Unmarshal([]byte(fakeMain()), &dataAttribuitions))
+		assert.Equal(t, 1, len(dataAttribuitions.Data))
+		assert.Equal(t, "Rain", dataAttribuitions.Data[0].Name)
+		assert.Equal(t, "CC-BY-4.0", dataAttribuitions.Data[0].LicenceSpdx)
+	})
 }
 
 func fakeMain() string {
@@ -315,7 +1809,12 @@ func fakeMain() string {
 		return string(usecases.FormatJSON(nil, err))
 	}
 
-	storage, err := infra.NewStorage(path)
+	seeds, err := command.ParseSeeds(os.Args)
+	if err != nil {
+		return string(usecases.FormatJSON(nil, err))
+	}
+
+	storage, err := infra.NewStorage(path, seeds...)
 	if err != nil {
 		return string(usecases.FormatJSON(nil, err))
 	}
@@ -342,6 +1841,24 @@ type _ResponseAttribuition struct {
 	Data    []domain.Attribuition `json:"data"`
 }
 
+type _ResponseText struct {
+	Status  string  `json:"status"`
+	Message *string `json:"message,omitempty"`
+	Data    string  `json:"data"`
+}
+
+type _ResponseImport struct {
+	Status  string              `json:"status"`
+	Message *string             `json:"message,omitempty"`
+	Data    domain.ImportReport `json:"data"`
+}
+
+func writeProjectFile(t *testing.T, root string, name string, content string) {
+	path := filepath.Join(root, filepath.FromSlash(name))
+	assert.NoError(t, os.MkdirAll(filepath.Dir(path), 0o755))
+	assert.NoError(t, os.WriteFile(path, []byte(content), 0o644))
+}
+
 func assertHasType(t *testing.T, name string, list []domain.Type) {
 	for _, type_ := range list {
 		if type_.Name == name {
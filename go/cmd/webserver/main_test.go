@@ -4,9 +4,13 @@ import (
 	"io"
 	"net/http"
 	"os"
+	"strconv"
 	"strings"
 	"testing"
 	"time"
+
+	"github.com/marcosbitetti/godot-manage-attribuitions-plugin/intrenal/domain"
+	"github.com/marcosbitetti/godot-manage-attribuitions-plugin/intrenal/infra"
 )
 
 func TestMain(t *testing.T) {
@@ -39,6 +43,54 @@ func TestMain(t *testing.T) {
 		}
 	})
 
+	t.Run("should stream attachments", func(t *testing.T) {
+		storage, err := infra.NewStorage(databasePath)
+		if err != nil {
+			t.Fatalf("Failed to open storage: %v", err)
+		}
+		defer storage.CloseDatabase()
+		credit, err := storage.AddAttribuition(domain.Attribuition{Name: "Forest", FileName: "forest.png", Type: "Texture",
+			Author: "Ana", Link: "https://example.com/forest", Licence: "MIT"})
+		if err != nil {
+			t.Fatalf("Failed to add attribuition: %v", err)
+		}
+		content := strings.Repeat("licence text ", 10000)
+		id, err := storage.AddAttachment(domain.Attachment{Credit: credit, Name: "LICENSE.txt", MimeType: "text/plain",
+			Size: int64(len(content)), Sha256: "abc"}, []byte(content))
+		if err != nil {
+			t.Fatalf("Failed to add attachment: %v", err)
+		}
+
+		resp, responseBody := makeRequest(t, baseUrl+"attachments/"+strconv.FormatInt(id, 10), http.StatusOK)
+		if responseBody != content {
+			t.Errorf("Expected the attachment content, got %d bytes", len(responseBody))
+		}
+		if disposition := resp.Header.Get("Content-Disposition"); disposition != `inline; filename=LICENSE.txt` {
+			t.Errorf("Expected an inline disposition, got %s", disposition)
+		}
+		if contentType := resp.Header.Get("Content-Type"); contentType != "text/plain" {
+			t.Errorf("Expected text/plain, got %s", contentType)
+		}
+		if sniff := resp.Header.Get("X-Content-Type-Options"); sniff != "nosniff" {
+			t.Errorf("Expected nosniff, got %s", sniff)
+		}
+
+		page := "<script>alert(1)</script>"
+		id, err = storage.AddAttachment(domain.Attachment{Credit: credit, Name: "page.html", MimeType: "text/html",
+			Size: int64(len(page)), Sha256: "def"}, []byte(page))
+		if err != nil {
+			t.Fatalf("Failed to add attachment: %v", err)
+		}
+		resp, _ = makeRequest(t, baseUrl+"attachments/"+strconv.FormatInt(id, 10), http.StatusOK)
+		if disposition := resp.Header.Get("Content-Disposition"); disposition != `attachment; filename=page.html` {
+			t.Errorf("Expected an attachment disposition, got %s", disposition)
+		}
+		if contentType := resp.Header.Get("Content-Type"); contentType != "application/octet-stream" {
+			t.Errorf("Expected application/octet-stream, got %s", contentType)
+		}
+		makeRequest(t, baseUrl+"attachments/999", http.StatusNotFound)
+	})
+
 }
 
 func waitServer(t *testing.T, url string) {
@@ -27,6 +27,14 @@ func NewType(id int64, name string) *Type {
 	}
 }
 
+func NewAuthor(name string) *Author {
+	return &Author{
+		Name:    name,
+		Aliases: make([]string, 0),
+		Social:  make([]string, 0),
+	}
+}
+
 func NewLicence(id int64, name string, link string) *Licence {
 	return &Licence{
 		Id:   id,
@@ -35,6 +43,17 @@ func NewLicence(id int64, name string, link string) *Licence {
 	}
 }
 
+// NewLicenceTerms returns the terms assumed for licences nobody described yet:
+// attribution required, commercial use and derivatives allowed.
+func NewLicenceTerms() LicenceTerms {
+	return LicenceTerms{
+		RequiresAttribution: true,
+		AllowsCommercial:    true,
+		AllowsDerivatives:   true,
+		CopyleftScope:       CopyleftNone,
+	}
+}
+
 func NewQuery(raw string) (*Query, error) {
 	q := Query{}
 	if err := json.Unmarshal([]byte(raw), &q); err != nil {
@@ -45,3 +64,19 @@ func NewQuery(raw string) (*Query, error) {
 	}
 	return &q, nil
 }
+
+func NewImportReport() *ImportReport {
+	return &ImportReport{
+		Created:   make([]string, 0),
+		Merged:    make([]string, 0),
+		Unchanged: make([]string, 0),
+		Conflicts: make([]ImportConflict, 0),
+	}
+}
+
+func NewComplianceReport(profile ProjectProfile) *ComplianceReport {
+	return &ComplianceReport{
+		Profile:    profile,
+		Violations: make([]ComplianceViolation, 0),
+	}
+}
@@ -1,28 +1,363 @@
 package domain
 
 type Attribuition struct {
+	Id           int64        `json:"_id"`
+	Name         string       `json:"name"`
+	FileName     string       `json:"filename"`
+	Type         string       `json:"type"`
+	Author       string       `json:"author"`
+	Link         string       `json:"link"`
+	Licence      string       `json:"licence"`
+	LicenceUrl   string       `json:"licenceUrl"`
+	LicenceSpdx  string       `json:"licenceSpdx"`
+	LicenceTerms LicenceTerms `json:"licenceTerms"`
+	// LicenceTemplate is the attribution template of the licence, used to
+	// compute AttributionText.
+	LicenceTemplate     string `json:"-"`
+	AttributionOverride string `json:"attributionOverride"`
+	// Modified tells if the asset was changed, UpstreamLink points to the
+	// original file.
+	Modified          bool            `json:"modified"`
+	ModificationNotes string          `json:"modificationNotes"`
+	UpstreamLink      string          `json:"upstreamLink"`
+	AttributionText   AttributionText `json:"attributionText"`
+	// Authors are the people credited, Author keeps their names joined for
+	// display and search.
+	Authors []Author `json:"authors"`
+	// Source is the name of the site the asset came from.
+	Source string `json:"source"`
+	// Links are the extra typed links of the attribuition, Link and
+	// UpstreamLink stay in their own fields.
+	Links []Link `json:"links"`
+	// Tags are free labels, like "placeholder" or "dlc1".
+	Tags []string `json:"tags"`
+	// Fields are the values of the custom fields by name, typed as described
+	// by their FieldDefinition.
+	Fields map[string]interface{} `json:"fields"`
+	// Permissions are the grants of the copyright holder, required by the
+	// licences with RequiresPermission.
+	Permissions []Permission `json:"permissions"`
+	// Purchase is kept for paid assets only, it is private and never goes
+	// to the credits exports.
+	Purchase *Purchase `json:"purchase"`
+	// ValidUntil is the last day the licence is valid and RenewBy the day
+	// it must be renewed, both as YYYY-MM-DD. Expired is set when ValidUntil
+	// has passed.
+	ValidUntil string `json:"validUntil"`
+	RenewBy    string `json:"renewBy"`
+	Expired    bool   `json:"expired"`
+	// ExternalId is the id of the asset in another system, like the manifest
+	// of an asset pipeline.
+	ExternalId string `json:"externalId"`
+}
+
+const (
+	KeyFileName   = "filename"
+	KeyExternalId = "externalId"
+	KeyLink       = "link"
+)
+
+// AttribuitionKeys are the natural keys an attribuition is matched by when
+// upserted: the filename, the external id, or the link together with the name.
+var AttribuitionKeys = []string{KeyFileName, KeyExternalId, KeyLink}
+
+// Purchase records how a paid asset was bought. Date is formatted as
+// YYYY-MM-DD and Account is the store account used to buy it.
+type Purchase struct {
+	Price    float64 `json:"price"`
+	Currency string  `json:"currency"`
+	Date     string  `json:"date"`
+	OrderId  string  `json:"orderId"`
+	Seats    int     `json:"seats"`
+	Account  string  `json:"account"`
+}
+
+// Link is a typed URL of an attribuition, Kind is one of LinkKinds.
+type Link struct {
+	Id    int64  `json:"_id"`
+	Kind  string `json:"kind"`
+	Url   string `json:"url"`
+	Label string `json:"label"`
+}
+
+const (
+	LinkSource   = "source"
+	LinkAuthor   = "author"
+	LinkDonate   = "donate"
+	LinkUpstream = "upstream"
+	LinkProof    = "proof"
+)
+
+// LinkKinds lists where a link points to: the download page, the author
+// homepage, a donation page, the original file or a licence proof.
+var LinkKinds = []string{LinkSource, LinkAuthor, LinkDonate, LinkUpstream, LinkProof}
+
+// Permission is a use granted by the copyright holder of an attribuition.
+// Channel tells how it was granted, like an email or a contract, Scope
+// describes where the asset can be used and Proof points to the kept evidence,
+// like an exported email or a screenshot.
+type Permission struct {
+	Id          int64  `json:"_id"`
+	Credit      int64  `json:"credit"`
+	Grantor     string `json:"grantor"`
+	GrantedAt   string `json:"grantedAt"`
+	Channel     string `json:"channel"`
+	Scope       string `json:"scope"`
+	Commercial  bool   `json:"commercial"`
+	Derivatives bool   `json:"derivatives"`
+	Text        string `json:"text"`
+	Proof       string `json:"proof"`
+}
+
+// Attachment is a file kept with an attribuition, like a receipt or a saved
+// licence page. The content is stored apart and Sha256 is its hex digest.
+type Attachment struct {
+	Id       int64  `json:"_id"`
+	Credit   int64  `json:"credit"`
+	Name     string `json:"name"`
+	MimeType string `json:"mimeType"`
+	Size     int64  `json:"size"`
+	Sha256   string `json:"sha256"`
+}
+
+// Author is a person or studio credited by attribuitions. Aliases are other
+// names found for the same author, like a domain or a full name.
+type Author struct {
+	Id       int64    `json:"_id"`
+	Name     string   `json:"name"`
+	Aliases  []string `json:"aliases"`
+	Homepage string   `json:"homepage"`
+	Contact  string   `json:"contact"`
+	Social   []string `json:"social"`
+	Credits  int      `json:"credits,omitempty"`
+}
+
+// AttributionText is the TASL (Title, Author, Source, Licence) credit line of
+// an attribuition in every supported markup.
+type AttributionText struct {
+	Plain    string `json:"plain"`
+	Markdown string `json:"markdown"`
+	Html     string `json:"html"`
+	BBCode   string `json:"bbcode"`
+}
+
+// Type is a category of assets. Parent is the _id of the parent type, 0 for
+// the top level ones, and Children are only filled when listing the tree.
+// DefaultLicence is given to the attribuitions added without licence and
+// Requires lists what the attribuitions of the type must have.
+type Type struct {
+	Id             int64    `json:"_id"`
+	Name           string   `json:"name"`
+	Parent         int64    `json:"parent"`
+	DefaultLicence string   `json:"defaultLicence"`
+	Requires       []string `json:"requires"`
+	Children       []Type   `json:"children,omitempty"`
+}
+
+// Requirements an attribuition type can have besides the name and licence
+// every attribuition needs.
+const (
+	RequireLink        = "link"
+	RequireAuthor      = "author"
+	RequireFileName    = "filename"
+	RequireSource      = "source"
+	RequireLicenceText = "licenceText"
+)
+
+// Requirements lists the known type requirements.
+var Requirements = []string{RequireLink, RequireAuthor, RequireFileName, RequireSource, RequireLicenceText}
+
+// DefaultRequirements are the requirements of the types added without them.
+var DefaultRequirements = []string{RequireLink, RequireAuthor}
+
+type Licence struct {
+	Id      int64  `json:"_id"`
+	SpdxId  string `json:"spdx"`
+	Name    string `json:"name"`
+	Link    string `json:"link"`
+	Text    string `json:"text,omitempty"`
+	Summary string `json:"summary"`
+	// AttributionTemplate overrides the default TASL template, see the
+	// README for the placeholders.
+	AttributionTemplate string `json:"attributionTemplate"`
+	LicenceTerms
+}
+
+// LicenceTerms are the obligations of a licence. CopyleftScope is one of the
+// CopyleftScopes.
+type LicenceTerms struct {
+	RequiresAttribution bool   `json:"requiresAttribution"`
+	AllowsCommercial    bool   `json:"allowsCommercial"`
+	ShareAlike          bool   `json:"shareAlike"`
+	AllowsDerivatives   bool   `json:"allowsDerivatives"`
+	RequiresLicenceText bool   `json:"requiresLicenceText"`
+	CopyleftScope       string `json:"copyleftScope"`
+	// RequiresPermission marks licences, like "All Rights Reserved", that
+	// only allow the use granted by the copyright holder.
+	RequiresPermission bool `json:"requiresPermission"`
+}
+
+const (
+	CopyleftNone       = "none"
+	CopyleftFile       = "file"
+	CopyleftLibrary    = "library"
+	CopyleftDerivative = "derivative"
+	CopyleftProject    = "project"
+)
+
+// CopyleftScopes lists how far the share-alike obligation of a licence reaches,
+// from nothing to the whole project.
+var CopyleftScopes = []string{CopyleftNone, CopyleftFile, CopyleftLibrary, CopyleftDerivative, CopyleftProject}
+
+// Query filters the attribuitions, empty fields don't filter. Text also
+// searches the custom field values. Tags keeps the attribuitions with all the
+// tags and ExcludeTags drops the ones with any. Type also keeps the
+// attribuitions of its descendant types.
+type Query struct {
+	Text        string   `json:"text"`
+	Order       string   `json:"order"`
+	Source      string   `json:"source"`
+	Type        string   `json:"type"`
+	Tags        []string `json:"tags"`
+	ExcludeTags []string `json:"excludeTags"`
+	// Fields keeps the attribuitions with these custom field values.
+	Fields map[string]interface{} `json:"fields"`
+}
+
+// FieldDefinition describes a custom field of the attribuitions. Options are
+// the values allowed by the enum fields.
+type FieldDefinition struct {
+	Id       int64    `json:"_id"`
+	Name     string   `json:"name"`
+	Type     string   `json:"type"`
+	Required bool     `json:"required"`
+	Options  []string `json:"options"`
+}
+
+const (
+	FieldString = "string"
+	FieldNumber = "number"
+	FieldDate   = "date"
+	FieldBool   = "bool"
+	FieldEnum   = "enum"
+)
+
+// FieldTypes lists the types of the custom fields, dates are YYYY-MM-DD.
+var FieldTypes = []string{FieldString, FieldNumber, FieldDate, FieldBool, FieldEnum}
+
+type Tag struct {
+	Id      int64  `json:"_id"`
+	Name    string `json:"name"`
+	Credits int    `json:"credits"`
+}
+
+type ImportConflict struct {
+	FileName string `json:"filename"`
+	Field    string `json:"field"`
+	Current  string `json:"current"`
+	Found    string `json:"found"`
+	Source   string `json:"source"`
+}
+
+type ImportReport struct {
+	Created   []string         `json:"created"`
+	Merged    []string         `json:"merged"`
+	Unchanged []string         `json:"unchanged"`
+	Conflicts []ImportConflict `json:"conflicts"`
+}
+
+// ProjectProfile describes the game the attribuitions belong to. An empty
+// Licence means the project is closed source.
+type ProjectProfile struct {
+	Commercial bool     `json:"commercial"`
+	Licence    string   `json:"licence"`
+	Platforms  []string `json:"platforms"`
+}
+
+type ComplianceViolation struct {
+	Rule     string `json:"rule"`
+	Id       int64  `json:"_id"`
+	Name     string `json:"name"`
+	FileName string `json:"filename"`
+	Licence  string `json:"licence"`
+	Message  string `json:"message"`
+}
+
+type ComplianceReport struct {
+	Profile    ProjectProfile        `json:"profile"`
+	Checked    int                   `json:"checked"`
+	Violations []ComplianceViolation `json:"violations"`
+}
+
+// Source is a site assets are downloaded from. DefaultLicence is the name of
+// the licence suggested for its assets, empty when the site has many.
+type Source struct {
+	Id             int64  `json:"_id"`
+	Name           string `json:"name"`
+	BaseUrl        string `json:"baseUrl"`
+	DefaultLicence string `json:"defaultLicence"`
+	Credits        int    `json:"credits,omitempty"`
+}
+
+type SourceReport struct {
+	Source   string         `json:"source"`
+	Credits  int            `json:"credits"`
+	Licences map[string]int `json:"licences"`
+}
+
+// PurchaseTotal sums the purchases of a group, Totals are keyed by currency.
+type PurchaseTotal struct {
+	Key       string             `json:"key"`
+	Purchases int                `json:"purchases"`
+	Totals    map[string]float64 `json:"totals"`
+}
+
+type PurchaseReport struct {
+	Sources  []PurchaseTotal `json:"sources"`
+	Licences []PurchaseTotal `json:"licences"`
+	Months   []PurchaseTotal `json:"months"`
+}
+
+// ExpiringCredit is an attribuition whose licence ends or must be renewed
+// soon, DaysLeft counts to the nearest of both dates and is negative when it
+// has passed.
+type ExpiringCredit struct {
 	Id         int64  `json:"_id"`
 	Name       string `json:"name"`
 	FileName   string `json:"filename"`
-	Type       string `json:"type"`
-	Author     string `json:"author"`
-	Link       string `json:"link"`
 	Licence    string `json:"licence"`
-	LicenceUrl string `json:"licenceUrl"`
+	ValidUntil string `json:"validUntil"`
+	RenewBy    string `json:"renewBy"`
+	Expired    bool   `json:"expired"`
+	DaysLeft   int    `json:"daysLeft"`
 }
 
-type Type struct {
-	Id   int64  `json:"_id"`
-	Name string `json:"name"`
+// SeedPack holds the licences, types, sources and custom fields a database
+// starts with. Types name their parent, and the types and sources name their
+// default licence.
+type SeedPack struct {
+	Name     string            `json:"name"`
+	Licences []Licence         `json:"licences"`
+	Types    []SeedType        `json:"types"`
+	Sources  []Source          `json:"sources"`
+	Fields   []FieldDefinition `json:"fields"`
 }
 
-type Licence struct {
-	Id   int64  `json:"_id"`
-	Name string `json:"name"`
-	Link string `json:"link"`
+// SeedType is a type of a seed pack, without requires its attribuitions need
+// the DefaultRequirements.
+type SeedType struct {
+	Name           string   `json:"name"`
+	Parent         string   `json:"parent"`
+	DefaultLicence string   `json:"defaultLicence"`
+	Requires       []string `json:"requires"`
 }
 
-type Query struct {
-	Text  string `json:"text"`
-	Order string `json:"order"`
+// SeedReport counts what a seed pack added, Skipped counts the entries
+// already registered.
+type SeedReport struct {
+	Licences int `json:"licences"`
+	Types    int `json:"types"`
+	Sources  int `json:"sources"`
+	Fields   int `json:"fields"`
+	Skipped  int `json:"skipped"`
 }
@@ -0,0 +1,141 @@
+package infra
+
+import (
+	"database/sql"
+	"io"
+
+	"github.com/pkg/errors"
+
+	"github.com/marcosbitetti/godot-manage-attribuitions-plugin/intrenal/domain"
+)
+
+// attachmentChunk is how many bytes of an attachment are read at once, so big
+// files are never loaded whole in memory.
+const attachmentChunk = 64 * 1024
+
+// ListAttachments returns the attachments of a credit, or of every credit when
+// credit is zero. The contents are not read.
+func (s *Storage) ListAttachments(credit int64) ([]domain.Attachment, error) {
+	s.locker.Lock()
+	defer s.locker.Unlock()
+
+	if credit == 0 {
+		return listAttachments(s.ex, "", nil)
+	}
+	return listAttachments(s.ex, `WHERE credit_id = ?`, []interface{}{credit})
+}
+
+// GetAttachment returns an attachment by id without its content, or nil when
+// missing.
+func (s *Storage) GetAttachment(id int64) (*domain.Attachment, error) {
+	s.locker.Lock()
+	defer s.locker.Unlock()
+
+	list, err := listAttachments(s.ex, `WHERE _id = ?`, []interface{}{id})
+	if err != nil || len(list) == 0 {
+		return nil, err
+	}
+	return &list[0], nil
+}
+
+// AddAttachment stores a file of a credit and returns its id.
+func (s *Storage) AddAttachment(attachment domain.Attachment, data []byte) (int64, error) {
+	s.locker.Lock()
+	defer s.locker.Unlock()
+
+	stmt, err := s.ex.Prepare(`
+		INSERT INTO attachments(credit_id, name, mime_type, size, sha256, data)
+		VALUES(?, ?, ?, ?, ?, ?)
+	`)
+	if err != nil {
+		return 0, errors.Wrap(err, "cant prepare to add attachment")
+	}
+	defer func() {
+		if err := stmt.Close(); err != nil {
+			panic(errors.Wrap(err, "cant close prepare to add attachment").Error())
+		}
+	}()
+	result, err := stmt.Exec(attachment.Credit, attachment.Name, attachment.MimeType,
+		attachment.Size, attachment.Sha256, data)
+	if err != nil {
+		return 0, errors.Wrap(err, "cant exec to add attachment")
+	}
+	return result.LastInsertId()
+}
+
+// CopyAttachment writes the content of an attachment to w, chunk by chunk.
+func (s *Storage) CopyAttachment(id int64, w io.Writer) error {
+	for offset := int64(1); ; offset += attachmentChunk {
+		chunk, err := s.attachmentChunk(id, offset)
+		if err != nil {
+			return err
+		}
+		if len(chunk) == 0 {
+			return nil
+		}
+		if _, err := w.Write(chunk); err != nil {
+			return errors.Wrap(err, "cant write attachment")
+		}
+	}
+}
+
+// attachmentChunk reads the content of an attachment from the 1-based offset.
+func (s *Storage) attachmentChunk(id int64, offset int64) ([]byte, error) {
+	s.locker.Lock()
+	defer s.locker.Unlock()
+
+	var chunk []byte
+	err := s.ex.QueryRow(`SELECT substr(data, ?, ?) FROM attachments WHERE _id = ?`, offset, attachmentChunk, id).
+		Scan(&chunk)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, errors.Wrap(err, "cant read attachment")
+	}
+	return chunk, nil
+}
+
+func (s *Storage) RemoveAttachment(id int64) error {
+	s.locker.Lock()
+	defer s.locker.Unlock()
+
+	stmt, err := s.ex.Prepare(`DELETE FROM attachments WHERE _id = ?`)
+	if err != nil {
+		return errors.Wrap(err, "cant prepare to remove attachment")
+	}
+	defer func() {
+		if err := stmt.Close(); err != nil {
+			panic(errors.Wrap(err, "cant close prepare to remove attachment").Error())
+		}
+	}()
+	_, err = stmt.Exec(id)
+	if err != nil {
+		return errors.Wrap(err, "cant exec to remove attachment")
+	}
+	return nil
+}
+
+func listAttachments(ex executor, whereClause string, args []interface{}) ([]domain.Attachment, error) {
+	rows, err := ex.Query(`
+		SELECT _id, credit_id, name, mime_type, size, sha256
+		FROM attachments `+whereClause+` ORDER BY credit_id, _id
+	`, args...)
+	if err != nil {
+		return nil, errors.Wrap(err, "cant read rows from attachments")
+	}
+	defer func() {
+		if err := rows.Close(); err != nil {
+			panic(errors.Wrap(err, "cant close rows from attachments").Error())
+		}
+	}()
+	list := make([]domain.Attachment, 0)
+	for rows.Next() {
+		data := domain.Attachment{}
+		if err := rows.Scan(&data.Id, &data.Credit, &data.Name, &data.MimeType, &data.Size, &data.Sha256); err != nil {
+			return nil, errors.Wrap(err, "cant read row from attachments")
+		}
+		list = append(list, data)
+	}
+	return list, nil
+}
@@ -0,0 +1,386 @@
+package infra
+
+import (
+	"database/sql"
+	"strings"
+
+	"github.com/marcosbitetti/godot-manage-attribuitions-plugin/intrenal/domain"
+	"github.com/pkg/errors"
+)
+
+func (s *Storage) ListAuthors() ([]domain.Author, error) {
+	s.locker.Lock()
+	defer s.locker.Unlock()
+
+	return listAuthors(s.ex, "", nil)
+}
+
+// GetAuthor returns an author by id, or nil when missing.
+func (s *Storage) GetAuthor(id int64) (*domain.Author, error) {
+	s.locker.Lock()
+	defer s.locker.Unlock()
+
+	list, err := listAuthors(s.ex, `WHERE a._id = ?`, []interface{}{id})
+	if err != nil || len(list) == 0 {
+		return nil, err
+	}
+	return &list[0], nil
+}
+
+// FindAuthor returns the author with the given name or alias, ignoring case,
+// or nil when missing.
+func (s *Storage) FindAuthor(name string) (*domain.Author, error) {
+	s.locker.Lock()
+	defer s.locker.Unlock()
+
+	return findAuthor(s.ex, name)
+}
+
+func (s *Storage) AddAuthor(author domain.Author) (int64, error) {
+	s.locker.Lock()
+	defer s.locker.Unlock()
+
+	return addAuthor(s.ex, author)
+}
+
+// UpdateAuthor changes an author and the author names of its credits.
+func (s *Storage) UpdateAuthor(author domain.Author) error {
+	s.locker.Lock()
+	defer s.locker.Unlock()
+
+	return s.inTransaction(func(tx *sql.Tx) error {
+		_, err := tx.Exec(`
+			UPDATE authors SET name=?, aliases=?, homepage=?, contact=?, social=? WHERE _id=?
+		`, author.Name, joinList(author.Aliases), author.Homepage, author.Contact, joinList(author.Social), author.Id)
+		if err != nil {
+			return errors.Wrap(err, "cant exec to update author")
+		}
+		return refreshAuthorCredits(tx, author.Id)
+	})
+}
+
+// DeleteAuthor removes an author from its credits and deletes it.
+func (s *Storage) DeleteAuthor(id int64) error {
+	s.locker.Lock()
+	defer s.locker.Unlock()
+
+	return s.inTransaction(func(tx *sql.Tx) error {
+		credits, err := authorCredits(tx, id)
+		if err != nil {
+			return err
+		}
+		if _, err := tx.Exec(`DELETE FROM credit_authors WHERE author_id = ?`, id); err != nil {
+			return errors.Wrap(err, "cant exec to unlink author")
+		}
+		if _, err := tx.Exec(`DELETE FROM authors WHERE _id = ?`, id); err != nil {
+			return errors.Wrap(err, "cant exec to delete author")
+		}
+		for _, credit := range credits {
+			if err := refreshCreditAuthor(tx, credit); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// MergeAuthors moves the credits of the sources to the target author, keeping
+// their names as aliases, and deletes the sources. It returns how many credits
+// gained the target author, the ones it was already credited on don't count.
+func (s *Storage) MergeAuthors(into int64, from []int64) (int64, error) {
+	s.locker.Lock()
+	defer s.locker.Unlock()
+
+	var moved int64
+	err := s.inTransaction(func(tx *sql.Tx) error {
+		targets, err := listAuthors(tx, `WHERE a._id = ?`, []interface{}{into})
+		if err != nil {
+			return err
+		}
+		if len(targets) == 0 {
+			return errors.New("author not found")
+		}
+		target := targets[0]
+		touched := make(map[int64]bool)
+		for _, id := range from {
+			if id == into {
+				continue
+			}
+			sources, err := listAuthors(tx, `WHERE a._id = ?`, []interface{}{id})
+			if err != nil {
+				return err
+			}
+			if len(sources) == 0 {
+				return errors.New("author not found")
+			}
+			source := sources[0]
+			target.Aliases = appendMissing(target.Aliases, target.Name, append([]string{source.Name}, source.Aliases...)...)
+			target.Social = appendMissing(target.Social, "", source.Social...)
+			if target.Homepage == "" {
+				target.Homepage = source.Homepage
+			}
+			if target.Contact == "" {
+				target.Contact = source.Contact
+			}
+
+			credits, err := authorCredits(tx, id)
+			if err != nil {
+				return err
+			}
+			for _, credit := range credits {
+				touched[credit] = true
+			}
+			result, err := tx.Exec(`
+				INSERT OR IGNORE INTO credit_authors(credit_id, author_id, position)
+				SELECT credit_id, ?, position FROM credit_authors WHERE author_id = ?
+			`, into, id)
+			if err != nil {
+				return errors.Wrap(err, "cant exec to move credits")
+			}
+			count, err := result.RowsAffected()
+			if err != nil {
+				return errors.Wrap(err, "cant count credits of author")
+			}
+			moved += count
+			if _, err := tx.Exec(`DELETE FROM credit_authors WHERE author_id = ?`, id); err != nil {
+				return errors.Wrap(err, "cant exec to unlink author")
+			}
+			if _, err := tx.Exec(`DELETE FROM authors WHERE _id = ?`, id); err != nil {
+				return errors.Wrap(err, "cant exec to delete author")
+			}
+		}
+		_, err = tx.Exec(`
+			UPDATE authors SET aliases=?, homepage=?, contact=?, social=? WHERE _id=?
+		`, joinList(target.Aliases), target.Homepage, target.Contact, joinList(target.Social), into)
+		if err != nil {
+			return errors.Wrap(err, "cant exec to update author")
+		}
+		for credit := range touched {
+			if err := refreshCreditAuthor(tx, credit); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return 0, err
+	}
+	return moved, nil
+}
+
+// appendMissing adds the values not found in list, ignoring case and skip.
+func appendMissing(list []string, skip string, values ...string) []string {
+	for _, value := range values {
+		if strings.EqualFold(value, skip) {
+			continue
+		}
+		found := false
+		for _, item := range list {
+			if strings.EqualFold(item, value) {
+				found = true
+				break
+			}
+		}
+		if !found {
+			list = append(list, value)
+		}
+	}
+	return list
+}
+
+func listAuthors(ex executor, whereClause string, args []interface{}) ([]domain.Author, error) {
+	list := make([]domain.Author, 0)
+	rows, err := ex.Query(`
+		SELECT a._id, a.name, a.aliases, a.homepage, a.contact, a.social,
+			(SELECT COUNT(*) FROM credit_authors ca WHERE ca.author_id = a._id)
+		FROM authors a
+		`+whereClause+`
+		ORDER BY a.name COLLATE NOCASE ASC
+	`, args...)
+	if err != nil {
+		return nil, errors.Wrap(err, "cant read rows from authors")
+	}
+	defer func() {
+		if err := rows.Close(); err != nil {
+			panic(errors.Wrap(err, "cant close rows from authors").Error())
+		}
+	}()
+	for rows.Next() {
+		data := domain.Author{}
+		var aliases, social string
+		if err := rows.Scan(&data.Id, &data.Name, &aliases, &data.Homepage, &data.Contact, &social, &data.Credits); err != nil {
+			return nil, errors.Wrap(err, "cant read row from authors")
+		}
+		data.Aliases = splitList(aliases)
+		data.Social = splitList(social)
+		list = append(list, data)
+	}
+	return list, nil
+}
+
+func findAuthor(ex executor, name string) (*domain.Author, error) {
+	name = strings.TrimSpace(name)
+	list, err := listAuthors(ex, "", nil)
+	if err != nil {
+		return nil, err
+	}
+	for _, author := range list {
+		if strings.EqualFold(author.Name, name) {
+			return &author, nil
+		}
+		for _, alias := range author.Aliases {
+			if strings.EqualFold(alias, name) {
+				return &author, nil
+			}
+		}
+	}
+	return nil, nil
+}
+
+func addAuthor(ex executor, author domain.Author) (int64, error) {
+	result, err := ex.Exec(`
+		INSERT INTO authors(name, aliases, homepage, contact, social) VALUES(?, ?, ?, ?, ?)
+	`, strings.TrimSpace(author.Name), joinList(author.Aliases), author.Homepage, author.Contact, joinList(author.Social))
+	if err != nil {
+		return 0, errors.Wrap(err, "cant exec to add author")
+	}
+	return result.LastInsertId()
+}
+
+func authorCredits(ex executor, id int64) ([]int64, error) {
+	rows, err := ex.Query(`SELECT credit_id FROM credit_authors WHERE author_id = ?`, id)
+	if err != nil {
+		return nil, errors.Wrap(err, "cant read credits of author")
+	}
+	defer func() {
+		if err := rows.Close(); err != nil {
+			panic(errors.Wrap(err, "cant close credits of author").Error())
+		}
+	}()
+	list := make([]int64, 0)
+	for rows.Next() {
+		var credit int64
+		if err := rows.Scan(&credit); err != nil {
+			return nil, errors.Wrap(err, "cant read credit of author")
+		}
+		list = append(list, credit)
+	}
+	return list, nil
+}
+
+// linkCreditAuthors replaces the authors of a credit. Authors without id are
+// found by name or alias and created when missing. A credit without authors
+// but with an author name gets that author.
+func linkCreditAuthors(ex executor, credit int64, attribuition domain.Attribuition) error {
+	authors := attribuition.Authors
+	if len(authors) == 0 && strings.TrimSpace(attribuition.Author) != "" {
+		authors = []domain.Author{{Name: attribuition.Author}}
+	}
+	if _, err := ex.Exec(`DELETE FROM credit_authors WHERE credit_id = ?`, credit); err != nil {
+		return errors.Wrap(err, "cant exec to unlink authors")
+	}
+	for position, author := range authors {
+		id := author.Id
+		if id != 0 {
+			var count int
+			if err := ex.QueryRow(`SELECT COUNT(*) FROM authors WHERE _id = ?`, id).Scan(&count); err != nil {
+				return errors.Wrap(err, "cant read author")
+			}
+			if count == 0 {
+				return errors.New("author not found")
+			}
+		} else {
+			found, err := findAuthor(ex, author.Name)
+			if err != nil {
+				return err
+			}
+			if found != nil {
+				id = found.Id
+			} else if id, err = addAuthor(ex, author); err != nil {
+				return err
+			}
+		}
+		_, err := ex.Exec(`
+			INSERT OR IGNORE INTO credit_authors(credit_id, author_id, position) VALUES(?, ?, ?)
+		`, credit, id, position)
+		if err != nil {
+			return errors.Wrap(err, "cant exec to link author")
+		}
+	}
+	return refreshCreditAuthor(ex, credit)
+}
+
+// refreshCreditAuthor stores the joined names of the credit authors in the
+// author column, used for display and search. The names are joined here, as
+// group_concat doesn't keep the order of the positions.
+func refreshCreditAuthor(ex executor, credit int64) error {
+	rows, err := ex.Query(`
+		SELECT a.name FROM credit_authors ca
+		JOIN authors a ON a._id = ca.author_id
+		WHERE ca.credit_id = ?
+		ORDER BY ca.position
+	`, credit)
+	if err != nil {
+		return errors.Wrap(err, "cant read credit authors")
+	}
+	names := make([]string, 0)
+	for rows.Next() {
+		var name string
+		if err := rows.Scan(&name); err != nil {
+			rows.Close()
+			return errors.Wrap(err, "cant read credit author")
+		}
+		names = append(names, name)
+	}
+	if err := rows.Close(); err != nil {
+		return errors.Wrap(err, "cant close credit authors")
+	}
+	if _, err := ex.Exec(`UPDATE credits SET author = ? WHERE _id = ?`, strings.Join(names, ", "), credit); err != nil {
+		return errors.Wrap(err, "cant exec to refresh credit author")
+	}
+	return nil
+}
+
+func refreshAuthorCredits(ex executor, author int64) error {
+	credits, err := authorCredits(ex, author)
+	if err != nil {
+		return err
+	}
+	for _, credit := range credits {
+		if err := refreshCreditAuthor(ex, credit); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// creditsAuthors returns the authors of every credit, in credit order.
+func creditsAuthors(ex executor) (map[int64][]domain.Author, error) {
+	rows, err := ex.Query(`
+		SELECT ca.credit_id, a._id, a.name, a.aliases, a.homepage, a.contact, a.social
+		FROM credit_authors ca
+		JOIN authors a ON a._id = ca.author_id
+		ORDER BY ca.credit_id, ca.position
+	`)
+	if err != nil {
+		return nil, errors.Wrap(err, "cant read authors of credits")
+	}
+	defer func() {
+		if err := rows.Close(); err != nil {
+			panic(errors.Wrap(err, "cant close authors of credits").Error())
+		}
+	}()
+	byCredit := make(map[int64][]domain.Author)
+	for rows.Next() {
+		var credit int64
+		var aliases, social string
+		data := domain.Author{}
+		if err := rows.Scan(&credit, &data.Id, &data.Name, &aliases, &data.Homepage, &data.Contact, &social); err != nil {
+			return nil, errors.Wrap(err, "cant read author of credit")
+		}
+		data.Aliases = splitList(aliases)
+		data.Social = splitList(social)
+		byCredit[credit] = append(byCredit[credit], data)
+	}
+	return byCredit, nil
+}
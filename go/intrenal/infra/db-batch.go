@@ -0,0 +1,17 @@
+package infra
+
+import (
+	"database/sql"
+)
+
+// Batch runs fn with a storage bound to one transaction. Every change made
+// through it is committed together when fn succeeds, or rolled back when it
+// fails.
+func (s *Storage) Batch(fn func(batch *Storage) error) error {
+	s.locker.Lock()
+	defer s.locker.Unlock()
+
+	return s.inTransaction(func(tx *sql.Tx) error {
+		return fn(&Storage{db: s.db, ex: tx, tx: tx})
+	})
+}
@@ -0,0 +1,200 @@
+package infra
+
+import (
+	"database/sql"
+	"strconv"
+
+	"github.com/pkg/errors"
+
+	"github.com/marcosbitetti/godot-manage-attribuitions-plugin/intrenal/domain"
+)
+
+func (s *Storage) ListFields() ([]domain.FieldDefinition, error) {
+	s.locker.Lock()
+	defer s.locker.Unlock()
+
+	return listFields(s.ex, "", nil)
+}
+
+// GetField returns a field definition by id, or nil when missing.
+func (s *Storage) GetField(id int64) (*domain.FieldDefinition, error) {
+	s.locker.Lock()
+	defer s.locker.Unlock()
+
+	list, err := listFields(s.ex, `WHERE _id = ?`, []interface{}{id})
+	if err != nil || len(list) == 0 {
+		return nil, err
+	}
+	return &list[0], nil
+}
+
+func (s *Storage) AddField(field domain.FieldDefinition) (int64, error) {
+	s.locker.Lock()
+	defer s.locker.Unlock()
+
+	stmt, err := s.ex.Prepare(`INSERT INTO field_definitions(name, type, required, options) VALUES(?, ?, ?, ?)`)
+	if err != nil {
+		return 0, errors.Wrap(err, "cant prepare to add field")
+	}
+	defer func() {
+		if err := stmt.Close(); err != nil {
+			panic(errors.Wrap(err, "cant close prepare to add field").Error())
+		}
+	}()
+	result, err := stmt.Exec(field.Name, field.Type, field.Required, joinList(field.Options))
+	if err != nil {
+		return 0, errors.Wrap(err, "cant exec to add field")
+	}
+	return result.LastInsertId()
+}
+
+func (s *Storage) UpdateField(field domain.FieldDefinition) error {
+	s.locker.Lock()
+	defer s.locker.Unlock()
+
+	stmt, err := s.ex.Prepare(`UPDATE field_definitions SET name=?, type=?, required=?, options=? WHERE _id=?`)
+	if err != nil {
+		return errors.Wrap(err, "cant prepare to update field")
+	}
+	defer func() {
+		if err := stmt.Close(); err != nil {
+			panic(errors.Wrap(err, "cant close prepare to update field").Error())
+		}
+	}()
+	_, err = stmt.Exec(field.Name, field.Type, field.Required, joinList(field.Options), field.Id)
+	if err != nil {
+		return errors.Wrap(err, "cant exec to update field")
+	}
+	return nil
+}
+
+// DeleteField removes a field definition and its values.
+func (s *Storage) DeleteField(id int64) error {
+	s.locker.Lock()
+	defer s.locker.Unlock()
+
+	return s.inTransaction(func(tx *sql.Tx) error {
+		if _, err := tx.Exec(`DELETE FROM credit_fields WHERE field_id = ?`, id); err != nil {
+			return errors.Wrap(err, "cant exec to remove field values")
+		}
+		if _, err := tx.Exec(`DELETE FROM field_definitions WHERE _id = ?`, id); err != nil {
+			return errors.Wrap(err, "cant exec to delete field")
+		}
+		return nil
+	})
+}
+
+// FieldValues counts the credits with a value for the field.
+func (s *Storage) FieldValues(id int64) (int, error) {
+	s.locker.Lock()
+	defer s.locker.Unlock()
+
+	var count int
+	if err := s.ex.QueryRow(`SELECT COUNT(*) FROM credit_fields WHERE field_id = ?`, id).Scan(&count); err != nil {
+		return 0, errors.Wrap(err, "cant count field values")
+	}
+	return count, nil
+}
+
+func listFields(ex executor, whereClause string, args []interface{}) ([]domain.FieldDefinition, error) {
+	rows, err := ex.Query(`
+		SELECT _id, name, type, required, options
+		FROM field_definitions `+whereClause+` ORDER BY name COLLATE NOCASE ASC
+	`, args...)
+	if err != nil {
+		return nil, errors.Wrap(err, "cant read rows from fields")
+	}
+	defer func() {
+		if err := rows.Close(); err != nil {
+			panic(errors.Wrap(err, "cant close rows from fields").Error())
+		}
+	}()
+	list := make([]domain.FieldDefinition, 0)
+	for rows.Next() {
+		data := domain.FieldDefinition{}
+		var options string
+		if err := rows.Scan(&data.Id, &data.Name, &data.Type, &data.Required, &options); err != nil {
+			return nil, errors.Wrap(err, "cant read row from fields")
+		}
+		data.Options = splitList(options)
+		list = append(list, data)
+	}
+	return list, nil
+}
+
+// setCreditFields replaces the custom field values of a credit.
+func setCreditFields(ex executor, credit int64, fields map[string]interface{}) error {
+	if _, err := ex.Exec(`DELETE FROM credit_fields WHERE credit_id = ?`, credit); err != nil {
+		return errors.Wrap(err, "cant exec to clear fields")
+	}
+	for name, value := range fields {
+		if value == nil {
+			continue
+		}
+		_, err := ex.Exec(`
+			INSERT INTO credit_fields(credit_id, field_id, value)
+			VALUES(?, (SELECT _id FROM field_definitions WHERE name = ?), ?)
+		`, credit, name, FieldText(value))
+		if err != nil {
+			return errors.Wrap(err, "cant exec to set field")
+		}
+	}
+	return nil
+}
+
+// creditsFields returns the custom field values of every credit, typed by
+// their definitions.
+func creditsFields(ex executor) (map[int64]map[string]interface{}, error) {
+	rows, err := ex.Query(`
+		SELECT cf.credit_id, f.name, f.type, cf.value FROM credit_fields cf
+		JOIN field_definitions f ON f._id = cf.field_id
+	`)
+	if err != nil {
+		return nil, errors.Wrap(err, "cant read fields of credits")
+	}
+	defer func() {
+		if err := rows.Close(); err != nil {
+			panic(errors.Wrap(err, "cant close fields of credits").Error())
+		}
+	}()
+	byCredit := make(map[int64]map[string]interface{})
+	for rows.Next() {
+		var credit int64
+		var name, type_, value string
+		if err := rows.Scan(&credit, &name, &type_, &value); err != nil {
+			return nil, errors.Wrap(err, "cant read field of credit")
+		}
+		if byCredit[credit] == nil {
+			byCredit[credit] = make(map[string]interface{})
+		}
+		byCredit[credit][name] = fieldValue(type_, value)
+	}
+	return byCredit, nil
+}
+
+// FieldText is the stored text of a custom field value.
+func FieldText(value interface{}) string {
+	switch typed := value.(type) {
+	case float64:
+		return strconv.FormatFloat(typed, 'f', -1, 64)
+	case bool:
+		return strconv.FormatBool(typed)
+	case string:
+		return typed
+	}
+	return ""
+}
+
+func fieldValue(type_ string, text string) interface{} {
+	switch type_ {
+	case domain.FieldNumber:
+		if number, err := strconv.ParseFloat(text, 64); err == nil {
+			return number
+		}
+	case domain.FieldBool:
+		if flag, err := strconv.ParseBool(text); err == nil {
+			return flag
+		}
+	}
+	return text
+}
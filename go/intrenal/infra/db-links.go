@@ -0,0 +1,70 @@
+package infra
+
+import (
+	"github.com/marcosbitetti/godot-manage-attribuitions-plugin/intrenal/domain"
+	"github.com/pkg/errors"
+)
+
+// AddLink stores a typed link of a credit and returns its id.
+func (s *Storage) AddLink(credit int64, link domain.Link) (int64, error) {
+	s.locker.Lock()
+	defer s.locker.Unlock()
+
+	stmt, err := s.ex.Prepare(`INSERT INTO credit_links(credit_id, kind, url, label) VALUES(?, ?, ?, ?)`)
+	if err != nil {
+		return 0, errors.Wrap(err, "cant prepare to add link")
+	}
+	defer func() {
+		if err := stmt.Close(); err != nil {
+			panic(errors.Wrap(err, "cant close prepare to add link").Error())
+		}
+	}()
+	result, err := stmt.Exec(credit, link.Kind, link.Url, link.Label)
+	if err != nil {
+		return 0, errors.Wrap(err, "cant exec to add link")
+	}
+	return result.LastInsertId()
+}
+
+func (s *Storage) RemoveLink(id int64) error {
+	s.locker.Lock()
+	defer s.locker.Unlock()
+
+	stmt, err := s.ex.Prepare(`DELETE FROM credit_links WHERE _id = ?`)
+	if err != nil {
+		return errors.Wrap(err, "cant prepare to remove link")
+	}
+	defer func() {
+		if err := stmt.Close(); err != nil {
+			panic(errors.Wrap(err, "cant close prepare to remove link").Error())
+		}
+	}()
+	_, err = stmt.Exec(id)
+	if err != nil {
+		return errors.Wrap(err, "cant exec to remove link")
+	}
+	return nil
+}
+
+// creditsLinks returns the typed links of every credit, in insertion order.
+func creditsLinks(ex executor) (map[int64][]domain.Link, error) {
+	rows, err := ex.Query(`SELECT credit_id, _id, kind, url, label FROM credit_links ORDER BY credit_id, _id`)
+	if err != nil {
+		return nil, errors.Wrap(err, "cant read links of credits")
+	}
+	defer func() {
+		if err := rows.Close(); err != nil {
+			panic(errors.Wrap(err, "cant close links of credits").Error())
+		}
+	}()
+	byCredit := make(map[int64][]domain.Link)
+	for rows.Next() {
+		var credit int64
+		data := domain.Link{}
+		if err := rows.Scan(&credit, &data.Id, &data.Kind, &data.Url, &data.Label); err != nil {
+			return nil, errors.Wrap(err, "cant read link of credit")
+		}
+		byCredit[credit] = append(byCredit[credit], data)
+	}
+	return byCredit, nil
+}
@@ -0,0 +1,522 @@
+package infra
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+
+	"github.com/marcosbitetti/godot-manage-attribuitions-plugin/intrenal/domain"
+	"github.com/pkg/errors"
+)
+
+// migration changes the schema of a database created by an older version.
+type migration func(ctx context.Context, tx *sql.Tx) error
+
+// migrations run in order over every database, the count of the ones already
+// applied is kept in PRAGMA user_version. Never change or remove a released
+// migration, append a new one instead.
+var migrations = []migration{
+	execMigration(`ALTER TABLE licences ADD COLUMN text TEXT NOT NULL DEFAULT ''`),
+	execMigration(`ALTER TABLE licences ADD COLUMN summary TEXT NOT NULL DEFAULT ''`),
+	fillFirstLicencesTexts,
+	execMigration(`ALTER TABLE licences ADD COLUMN spdx_id TEXT NOT NULL DEFAULT ''`),
+	fillFirstLicencesSpdx,
+	execMigration(`ALTER TABLE licences ADD COLUMN requires_attribution INTEGER NOT NULL DEFAULT 1`),
+	execMigration(`ALTER TABLE licences ADD COLUMN allows_commercial INTEGER NOT NULL DEFAULT 1`),
+	execMigration(`ALTER TABLE licences ADD COLUMN share_alike INTEGER NOT NULL DEFAULT 0`),
+	execMigration(`ALTER TABLE licences ADD COLUMN allows_derivatives INTEGER NOT NULL DEFAULT 1`),
+	execMigration(`ALTER TABLE licences ADD COLUMN requires_licence_text INTEGER NOT NULL DEFAULT 0`),
+	execMigration(`ALTER TABLE licences ADD COLUMN copyleft_scope TEXT NOT NULL DEFAULT 'none'`),
+	fillLicencesTerms,
+	execMigration(`
+		CREATE TABLE project (
+			_id 		INTEGER PRIMARY KEY NOT NULL,
+			commercial	INTEGER NOT NULL DEFAULT 1,
+			licence		TEXT NOT NULL DEFAULT '',
+			platforms	TEXT NOT NULL DEFAULT ''
+		)
+	`),
+	execMigration(`INSERT INTO project(_id) VALUES(1)`),
+	execMigration(`ALTER TABLE licences ADD COLUMN attribution_template TEXT NOT NULL DEFAULT ''`),
+	execMigration(`ALTER TABLE credits ADD COLUMN attribution_override TEXT NOT NULL DEFAULT ''`),
+	execMigration(`ALTER TABLE credits ADD COLUMN modified INTEGER NOT NULL DEFAULT 0`),
+	execMigration(`ALTER TABLE credits ADD COLUMN modification_notes TEXT NOT NULL DEFAULT ''`),
+	execMigration(`ALTER TABLE credits ADD COLUMN upstream_link TEXT NOT NULL DEFAULT ''`),
+	execMigration(`
+		CREATE TABLE authors (
+			_id 		INTEGER PRIMARY KEY NOT NULL,
+			name		TEXT NOT NULL,
+			aliases		TEXT NOT NULL DEFAULT '',
+			homepage	TEXT NOT NULL DEFAULT '',
+			contact		TEXT NOT NULL DEFAULT '',
+			social		TEXT NOT NULL DEFAULT ''
+		)
+	`),
+	execMigration(`
+		CREATE TABLE credit_authors (
+			credit_id	INTEGER NOT NULL,
+			author_id	INTEGER NOT NULL,
+			position	INTEGER NOT NULL DEFAULT 0,
+			PRIMARY KEY (credit_id, author_id),
+			FOREIGN KEY (credit_id)
+				REFERENCES credits (_id),
+			FOREIGN KEY (author_id)
+				REFERENCES authors (_id)
+		)
+	`),
+	fillAuthors,
+	execMigration(`
+		CREATE TABLE credit_links (
+			_id 		INTEGER PRIMARY KEY NOT NULL,
+			credit_id	INTEGER NOT NULL,
+			kind		TEXT NOT NULL,
+			url			TEXT NOT NULL,
+			label		TEXT NOT NULL DEFAULT '',
+			FOREIGN KEY (credit_id)
+				REFERENCES credits (_id)
+		)
+	`),
+	execMigration(`
+		CREATE TABLE sources (
+			_id 				INTEGER PRIMARY KEY NOT NULL,
+			name				TEXT NOT NULL,
+			base_url			TEXT NOT NULL,
+			default_licence_id	INTEGER,
+			FOREIGN KEY (default_licence_id)
+				REFERENCES licences (_id)
+		)
+	`),
+	execMigration(`ALTER TABLE credits ADD COLUMN source_id INTEGER`),
+	fillFirstSources,
+	execMigration(`ALTER TABLE credits ADD COLUMN purchase_price REAL`),
+	execMigration(`ALTER TABLE credits ADD COLUMN purchase_currency TEXT`),
+	execMigration(`ALTER TABLE credits ADD COLUMN purchase_date TEXT`),
+	execMigration(`ALTER TABLE credits ADD COLUMN purchase_order TEXT`),
+	execMigration(`ALTER TABLE credits ADD COLUMN purchase_seats INTEGER`),
+	execMigration(`ALTER TABLE credits ADD COLUMN purchase_account TEXT`),
+	execMigration(`ALTER TABLE credits ADD COLUMN valid_until TEXT NOT NULL DEFAULT ''`),
+	execMigration(`ALTER TABLE credits ADD COLUMN renew_by TEXT NOT NULL DEFAULT ''`),
+	execMigration(`ALTER TABLE licences ADD COLUMN requires_permission INTEGER NOT NULL DEFAULT 0`),
+	execMigration(`
+		CREATE TABLE permissions (
+			_id INTEGER PRIMARY KEY AUTOINCREMENT,
+			credit_id INTEGER NOT NULL,
+			grantor TEXT NOT NULL,
+			granted_at TEXT NOT NULL DEFAULT '',
+			channel TEXT NOT NULL DEFAULT '',
+			scope TEXT NOT NULL DEFAULT '',
+			commercial INTEGER NOT NULL DEFAULT 0,
+			derivatives INTEGER NOT NULL DEFAULT 0,
+			text TEXT NOT NULL DEFAULT '',
+			proof TEXT NOT NULL DEFAULT '',
+			FOREIGN KEY (credit_id)
+				REFERENCES credits (_id)
+		)
+	`),
+	addAllRightsReserved,
+	execMigration(`
+		CREATE TABLE attachments (
+			_id INTEGER PRIMARY KEY AUTOINCREMENT,
+			credit_id INTEGER NOT NULL,
+			name TEXT NOT NULL,
+			mime_type TEXT NOT NULL,
+			size INTEGER NOT NULL,
+			sha256 TEXT NOT NULL,
+			data BLOB NOT NULL,
+			FOREIGN KEY (credit_id)
+				REFERENCES credits (_id)
+		)
+	`),
+	execMigration(`
+		CREATE TABLE tags (
+			_id INTEGER PRIMARY KEY AUTOINCREMENT,
+			name TEXT NOT NULL UNIQUE COLLATE NOCASE
+		)
+	`),
+	execMigration(`
+		CREATE TABLE credit_tags (
+			credit_id INTEGER NOT NULL,
+			tag_id INTEGER NOT NULL,
+			PRIMARY KEY (credit_id, tag_id),
+			FOREIGN KEY (credit_id)
+				REFERENCES credits (_id),
+			FOREIGN KEY (tag_id)
+				REFERENCES tags (_id)
+		)
+	`),
+	execMigration(`
+		CREATE TABLE field_definitions (
+			_id INTEGER PRIMARY KEY AUTOINCREMENT,
+			name TEXT NOT NULL UNIQUE COLLATE NOCASE,
+			type TEXT NOT NULL,
+			required INTEGER NOT NULL DEFAULT 0,
+			options TEXT NOT NULL DEFAULT ''
+		)
+	`),
+	execMigration(`
+		CREATE TABLE credit_fields (
+			credit_id INTEGER NOT NULL,
+			field_id INTEGER NOT NULL,
+			value TEXT NOT NULL,
+			PRIMARY KEY (credit_id, field_id),
+			FOREIGN KEY (credit_id)
+				REFERENCES credits (_id),
+			FOREIGN KEY (field_id)
+				REFERENCES field_definitions (_id)
+		)
+	`),
+	execMigration(`ALTER TABLE types ADD COLUMN parent_id INTEGER REFERENCES types (_id)`),
+	execMigration(`ALTER TABLE types ADD COLUMN default_licence_id INTEGER REFERENCES licences (_id)`),
+	execMigration(`ALTER TABLE types ADD COLUMN requires TEXT NOT NULL DEFAULT ''`),
+	fillTypesRules,
+	execMigration(`ALTER TABLE credits ADD COLUMN external_id TEXT NOT NULL DEFAULT ''`),
+	execMigration(`CREATE INDEX credits_external_id ON credits (external_id)`),
+	fillCreativeCommonsTexts,
+}
+
+func execMigration(statement string) migration {
+	return func(ctx context.Context, tx *sql.Tx) error {
+		_, err := tx.ExecContext(ctx, statement)
+		return err
+	}
+}
+
+func migrateDatabase(ctx context.Context, storage *Storage) error {
+	var version int
+	if err := storage.db.QueryRowContext(ctx, `PRAGMA user_version`).Scan(&version); err != nil {
+		return errors.Wrap(err, "cant read database version")
+	}
+	for ; version < len(migrations); version++ {
+		tx, err := storage.db.BeginTx(ctx, nil)
+		if err != nil {
+			return errors.Wrap(err, "cant begin migration")
+		}
+		if err := migrations[version](ctx, tx); err != nil {
+			tx.Rollback()
+			return errors.Wrapf(err, "cant apply migration %d", version+1)
+		}
+		if _, err := tx.ExecContext(ctx, fmt.Sprintf(`PRAGMA user_version = %d`, version+1)); err != nil {
+			tx.Rollback()
+			return errors.Wrap(err, "cant update database version")
+		}
+		if err := tx.Commit(); err != nil {
+			return errors.Wrapf(err, "cant commit migration %d", version+1)
+		}
+	}
+	return nil
+}
+
+// fillFirstLicencesTexts stores the bundled texts on the seeded licences of
+// databases created before licence texts existed.
+func fillFirstLicencesTexts(ctx context.Context, tx *sql.Tx) error {
+	licences := []struct {
+		Name    string
+		Summary string
+		Text    string
+	}{
+		{"Attribution 4.0 International (CC BY 4.0)",
+			"Share and adapt for any purpose, even commercially, giving appropriate credit and indicating changes.", ""},
+		{"Attribution-ShareAlike 4.0 International (CC BY-SA 4.0)",
+			"Share and adapt for any purpose, giving credit and distributing adaptations under the same licence.", ""},
+		{"Attribution-NonCommercial 4.0 International (CC BY-NC 4.0)",
+			"Share and adapt for non-commercial purposes only, giving appropriate credit.", ""},
+		{"Attribution-NonCommercial-ShareAlike 4.0 International (CC BY-NC-SA 4.0)",
+			"Share and adapt for non-commercial purposes only, giving credit and keeping the same licence.", ""},
+		{"Attribution-NoDerivatives 4.0 International (CC BY-ND 4.0)",
+			"Share unmodified copies for any purpose, giving appropriate credit. Adaptations can't be shared.", ""},
+		{"Attribution-NonCommercial-NoDerivatives 4.0 International (CC BY-NC-ND 4.0)",
+			"Share unmodified copies for non-commercial purposes only, giving appropriate credit.", ""},
+		{"CC0 1.0 Universal (CC0 1.0) - Public Domain Dedication",
+			"Public domain dedication, no conditions.", "CC0-1.0.txt"},
+		{"MIT",
+			"Permissive, keep the copyright and permission notice in all copies.", "MIT.txt"},
+		{"GNU General Public Licence",
+			"Copyleft, the whole work must be distributed under the GPL with its source code.", "GPL-3.0.txt"},
+		{"Attribution-NonCommercial-ShareAlike 3.0 Unported (CC BY-NC-SA 3.0)",
+			"Share and adapt for non-commercial purposes only, giving credit and keeping the same licence.", ""},
+		{"Attribution-NonCommercial-NoDerivs 3.0 Unported (CC BY-NC-ND 3.0)",
+			"Share unmodified copies for non-commercial purposes only, giving appropriate credit.", ""},
+		{"Attribution-ShareAlike 3.0 Unported (CC BY-SA 3.0)",
+			"Share and adapt for any purpose, giving credit and distributing adaptations under the same licence.", ""},
+		{"Attribution-NoDerivs 3.0 Unported (CC BY-ND 3.0)",
+			"Share unmodified copies for any purpose, giving appropriate credit. Adaptations can't be shared.", ""},
+		{"Attribution 3.0 Unported (CC BY 3.0)",
+			"Share and adapt for any purpose, even commercially, giving appropriate credit.", ""},
+		{"GNU Lesser General Public License (LGPL)",
+			"Weak copyleft, changes to the library must be shared, linking works may use any licence.", "LGPL-3.0.txt"},
+		{"Apache License 2.0",
+			"Permissive, keep the licence and NOTICE file and state significant changes.", "Apache-2.0.txt"},
+		{"Mozilla Public License 2.0",
+			"File level copyleft, modified MPL files must stay under the MPL.", "MPL-2.0.txt"},
+		{"Beerware",
+			"Do whatever you want while keeping the notice.", "Beerware.txt"},
+		{"Royalty Free",
+			"Use without paying royalties, check the terms of the store it was bought from.", ""},
+		{"Open Font License (OFL)",
+			"Use, modify and embed fonts freely, the fonts can't be sold by themselves.", "OFL-1.1.txt"},
+		{"OGA-BY 3.0 (Open Game Art)",
+			"Like CC BY 3.0 without the restriction on technical protection measures.", ""},
+		{"Free Standard (Sketchfab)",
+			"Sketchfab Store standard licence, use in your projects without redistributing the asset alone.", ""},
+	}
+	for _, licence := range licences {
+		_, err := tx.ExecContext(ctx, `
+			UPDATE licences SET text=?, summary=? WHERE name=? AND text='' AND summary=''
+		`, bundledLicenceText(licence.Text), licence.Summary, licence.Name)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// fillFirstLicencesSpdx sets the SPDX identifiers of the seeded licences.
+func fillFirstLicencesSpdx(ctx context.Context, tx *sql.Tx) error {
+	licences := [][2]string{
+		{"CC-BY-4.0", "Attribution 4.0 International (CC BY 4.0)"},
+		{"CC-BY-SA-4.0", "Attribution-ShareAlike 4.0 International (CC BY-SA 4.0)"},
+		{"CC-BY-NC-4.0", "Attribution-NonCommercial 4.0 International (CC BY-NC 4.0)"},
+		{"CC-BY-NC-SA-4.0", "Attribution-NonCommercial-ShareAlike 4.0 International (CC BY-NC-SA 4.0)"},
+		{"CC-BY-ND-4.0", "Attribution-NoDerivatives 4.0 International (CC BY-ND 4.0)"},
+		{"CC-BY-NC-ND-4.0", "Attribution-NonCommercial-NoDerivatives 4.0 International (CC BY-NC-ND 4.0)"},
+		{"CC0-1.0", "CC0 1.0 Universal (CC0 1.0) - Public Domain Dedication"},
+		{"MIT", "MIT"},
+		{"GPL-3.0-or-later", "GNU General Public Licence"},
+		{"CC-BY-NC-SA-3.0", "Attribution-NonCommercial-ShareAlike 3.0 Unported (CC BY-NC-SA 3.0)"},
+		{"CC-BY-NC-ND-3.0", "Attribution-NonCommercial-NoDerivs 3.0 Unported (CC BY-NC-ND 3.0)"},
+		{"CC-BY-SA-3.0", "Attribution-ShareAlike 3.0 Unported (CC BY-SA 3.0)"},
+		{"CC-BY-ND-3.0", "Attribution-NoDerivs 3.0 Unported (CC BY-ND 3.0)"},
+		{"CC-BY-3.0", "Attribution 3.0 Unported (CC BY 3.0)"},
+		{"LGPL-3.0-or-later", "GNU Lesser General Public License (LGPL)"},
+		{"Apache-2.0", "Apache License 2.0"},
+		{"MPL-2.0", "Mozilla Public License 2.0"},
+		{"Beerware", "Beerware"},
+		{"OFL-1.1", "Open Font License (OFL)"},
+	}
+	for _, licence := range licences {
+		_, err := tx.ExecContext(ctx, `
+			UPDATE licences SET spdx_id=? WHERE name=? AND spdx_id=''
+		`, licence[0], licence[1])
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// fillLicencesTerms describes the terms of every licence already recognized.
+func fillLicencesTerms(ctx context.Context, tx *sql.Tx) error {
+	rows, err := tx.QueryContext(ctx, `SELECT _id, spdx_id, name FROM licences`)
+	if err != nil {
+		return err
+	}
+	type row struct {
+		id     int64
+		spdxId string
+		name   string
+	}
+	list := make([]row, 0)
+	for rows.Next() {
+		data := row{}
+		if err := rows.Scan(&data.id, &data.spdxId, &data.name); err != nil {
+			rows.Close()
+			return err
+		}
+		list = append(list, data)
+	}
+	if err := rows.Close(); err != nil {
+		return err
+	}
+	for _, data := range list {
+		terms := LicenceTermsFor(data.spdxId, data.name)
+		_, err := tx.ExecContext(ctx, `
+			UPDATE licences SET
+				requires_attribution=?, allows_commercial=?, share_alike=?, allows_derivatives=?,
+				requires_licence_text=?, copyleft_scope=?
+			WHERE _id=?
+		`, terms.RequiresAttribution, terms.AllowsCommercial, terms.ShareAlike, terms.AllowsDerivatives,
+			terms.RequiresLicenceText, terms.CopyleftScope, data.id)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// fillAuthors turns the free-text authors of the credits into authors, the
+// same name written with other case or spaces is the same author.
+func fillAuthors(ctx context.Context, tx *sql.Tx) error {
+	rows, err := tx.QueryContext(ctx, `
+		SELECT _id, TRIM(author) FROM credits WHERE TRIM(COALESCE(author, '')) != '' ORDER BY _id
+	`)
+	if err != nil {
+		return err
+	}
+	type row struct {
+		id     int64
+		author string
+	}
+	list := make([]row, 0)
+	for rows.Next() {
+		data := row{}
+		if err := rows.Scan(&data.id, &data.author); err != nil {
+			rows.Close()
+			return err
+		}
+		list = append(list, data)
+	}
+	if err := rows.Close(); err != nil {
+		return err
+	}
+	authors := make(map[string]int64)
+	for _, data := range list {
+		key := strings.ToLower(data.author)
+		id, ok := authors[key]
+		if !ok {
+			result, err := tx.ExecContext(ctx, `INSERT INTO authors(name) VALUES(?)`, data.author)
+			if err != nil {
+				return err
+			}
+			if id, err = result.LastInsertId(); err != nil {
+				return err
+			}
+			authors[key] = id
+		}
+		_, err := tx.ExecContext(ctx, `
+			INSERT INTO credit_authors(credit_id, author_id, position) VALUES(?, ?, 0)
+		`, data.id, id)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// fillFirstSources seeds the sources and detects the source of the credits
+// already registered from their links. New databases, still without licences,
+// get their sources from the seed packs.
+func fillFirstSources(ctx context.Context, tx *sql.Tx) error {
+	var seeded bool
+	if err := tx.QueryRowContext(ctx, `SELECT EXISTS(SELECT 1 FROM licences)`).Scan(&seeded); err != nil {
+		return err
+	}
+	if !seeded {
+		return nil
+	}
+	firstSources := []struct {
+		Name           string
+		BaseUrl        string
+		DefaultLicence string
+	}{
+		{"itch.io", "https://itch.io", ""},
+		{"OpenGameArt", "https://opengameart.org", ""},
+		{"Sketchfab", "https://sketchfab.com", "Free Standard (Sketchfab)"},
+		{"Kenney", "https://kenney.nl", "CC0 1.0 Universal (CC0 1.0) - Public Domain Dedication"},
+		{"Godot Asset Library", "https://godotengine.org/asset-library", "MIT"},
+		{"Freesound", "https://freesound.org", ""},
+		{"Unity Asset Store", "https://assetstore.unity.com", ""},
+		{"Humble Bundle", "https://www.humblebundle.com", "Royalty Free"},
+	}
+	for _, source := range firstSources {
+		_, err := tx.ExecContext(ctx, `
+			INSERT INTO sources(name, base_url, default_licence_id)
+			VALUES(?, ?, (SELECT _id FROM licences WHERE name=?))
+		`, source.Name, source.BaseUrl, source.DefaultLicence)
+		if err != nil {
+			return err
+		}
+	}
+	sources, err := listSources(tx, "", nil)
+	if err != nil {
+		return err
+	}
+	rows, err := tx.QueryContext(ctx, `SELECT _id, COALESCE(link, '') FROM credits`)
+	if err != nil {
+		return err
+	}
+	detected := make(map[int64]int64)
+	for rows.Next() {
+		var id int64
+		var link string
+		if err := rows.Scan(&id, &link); err != nil {
+			rows.Close()
+			return err
+		}
+		if source := DetectSource(sources, link); source != nil {
+			detected[id] = source.Id
+		}
+	}
+	if err := rows.Close(); err != nil {
+		return err
+	}
+	for id, source := range detected {
+		if _, err := tx.ExecContext(ctx, `UPDATE credits SET source_id=? WHERE _id=?`, source, id); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// addAllRightsReserved adds the "All Rights Reserved" licence to databases
+// seeded by older versions, new databases get it with the first licences.
+func addAllRightsReserved(ctx context.Context, tx *sql.Tx) error {
+	var seeded bool
+	if err := tx.QueryRowContext(ctx, `SELECT EXISTS(SELECT 1 FROM licences)`).Scan(&seeded); err != nil {
+		return err
+	}
+	if !seeded {
+		return nil
+	}
+	var found bool
+	err := tx.QueryRowContext(ctx, `SELECT EXISTS(SELECT 1 FROM licences WHERE name=?)`, allRightsReserved).Scan(&found)
+	if err != nil || found {
+		return err
+	}
+	_, err = tx.ExecContext(ctx, `
+		INSERT INTO licences(spdx_id, name, link, summary,
+			requires_attribution, allows_commercial, share_alike, allows_derivatives,
+			requires_licence_text, copyleft_scope, requires_permission)
+		VALUES('', ?, 'https://en.wikipedia.org/wiki/All_rights_reserved',
+			'No use is allowed without the permission of the copyright holder.', 1, 0, 0, 0, 0, 'none', 1)
+	`, allRightsReserved)
+	return err
+}
+
+// fillTypesRules keeps the link and author required by the types already
+// registered, as they were before the rules moved to the types, and sets the
+// rules of the seeded ones.
+func fillTypesRules(ctx context.Context, tx *sql.Tx) error {
+	requires := []string{domain.RequireLink, domain.RequireAuthor}
+	_, err := tx.ExecContext(ctx, `UPDATE types SET requires=?`, joinList(requires))
+	if err != nil {
+		return err
+	}
+	font := []string{domain.RequireLink, domain.RequireAuthor, domain.RequireLicenceText}
+	if _, err := tx.ExecContext(ctx, `UPDATE types SET requires=? WHERE name='Font'`, joinList(font)); err != nil {
+		return err
+	}
+	_, err = tx.ExecContext(ctx, `
+		UPDATE types SET default_licence_id=(SELECT _id FROM licences WHERE name='MIT')
+		WHERE name='Code Snippet' AND default_licence_id IS NULL
+	`)
+	return err
+}
+
+// fillCreativeCommonsTexts stores the bundled legal codes on the Creative
+// Commons licences seeded before their texts were bundled.
+func fillCreativeCommonsTexts(ctx context.Context, tx *sql.Tx) error {
+	for _, spdxId := range []string{
+		"CC-BY-3.0", "CC-BY-4.0", "CC-BY-NC-3.0", "CC-BY-NC-4.0",
+		"CC-BY-NC-ND-3.0", "CC-BY-NC-ND-4.0", "CC-BY-NC-SA-3.0", "CC-BY-NC-SA-4.0",
+		"CC-BY-ND-3.0", "CC-BY-ND-4.0", "CC-BY-SA-3.0", "CC-BY-SA-4.0",
+	} {
+		_, err := tx.ExecContext(ctx, `
+			UPDATE licences SET text=? WHERE spdx_id=? AND text=''
+		`, bundledLicenceText(spdxId+".txt"), spdxId)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,140 @@
+package infra
+
+import (
+	"github.com/pkg/errors"
+
+	"github.com/marcosbitetti/godot-manage-attribuitions-plugin/intrenal/domain"
+)
+
+const permissionColumns = `_id, credit_id, grantor, granted_at, channel, scope,
+	commercial, derivatives, text, proof`
+
+// ListPermissions returns the permissions granted for a credit, or for every
+// credit when credit is zero.
+func (s *Storage) ListPermissions(credit int64) ([]domain.Permission, error) {
+	s.locker.Lock()
+	defer s.locker.Unlock()
+
+	if credit == 0 {
+		return listPermissions(s.ex, "", nil)
+	}
+	return listPermissions(s.ex, `WHERE credit_id = ?`, []interface{}{credit})
+}
+
+// GetPermission returns a permission by id, or nil when missing.
+func (s *Storage) GetPermission(id int64) (*domain.Permission, error) {
+	s.locker.Lock()
+	defer s.locker.Unlock()
+
+	list, err := listPermissions(s.ex, `WHERE _id = ?`, []interface{}{id})
+	if err != nil || len(list) == 0 {
+		return nil, err
+	}
+	return &list[0], nil
+}
+
+// AddPermission stores a permission granted for a credit and returns its id.
+func (s *Storage) AddPermission(permission domain.Permission) (int64, error) {
+	s.locker.Lock()
+	defer s.locker.Unlock()
+
+	stmt, err := s.ex.Prepare(`
+		INSERT INTO permissions(credit_id, grantor, granted_at, channel, scope,
+			commercial, derivatives, text, proof)
+		VALUES(?, ?, ?, ?, ?, ?, ?, ?, ?)
+	`)
+	if err != nil {
+		return 0, errors.Wrap(err, "cant prepare to add permission")
+	}
+	defer func() {
+		if err := stmt.Close(); err != nil {
+			panic(errors.Wrap(err, "cant close prepare to add permission").Error())
+		}
+	}()
+	result, err := stmt.Exec(permission.Credit, permission.Grantor, permission.GrantedAt, permission.Channel,
+		permission.Scope, permission.Commercial, permission.Derivatives, permission.Text, permission.Proof)
+	if err != nil {
+		return 0, errors.Wrap(err, "cant exec to add permission")
+	}
+	return result.LastInsertId()
+}
+
+func (s *Storage) UpdatePermission(permission domain.Permission) error {
+	s.locker.Lock()
+	defer s.locker.Unlock()
+
+	stmt, err := s.ex.Prepare(`
+		UPDATE permissions SET grantor=?, granted_at=?, channel=?, scope=?,
+			commercial=?, derivatives=?, text=?, proof=?
+		WHERE _id=?
+	`)
+	if err != nil {
+		return errors.Wrap(err, "cant prepare to update permission")
+	}
+	defer func() {
+		if err := stmt.Close(); err != nil {
+			panic(errors.Wrap(err, "cant close prepare to update permission").Error())
+		}
+	}()
+	_, err = stmt.Exec(permission.Grantor, permission.GrantedAt, permission.Channel, permission.Scope,
+		permission.Commercial, permission.Derivatives, permission.Text, permission.Proof, permission.Id)
+	if err != nil {
+		return errors.Wrap(err, "cant exec to update permission")
+	}
+	return nil
+}
+
+func (s *Storage) DeletePermission(id int64) error {
+	s.locker.Lock()
+	defer s.locker.Unlock()
+
+	stmt, err := s.ex.Prepare(`DELETE FROM permissions WHERE _id = ?`)
+	if err != nil {
+		return errors.Wrap(err, "cant prepare to delete permission")
+	}
+	defer func() {
+		if err := stmt.Close(); err != nil {
+			panic(errors.Wrap(err, "cant close prepare to delete permission").Error())
+		}
+	}()
+	_, err = stmt.Exec(id)
+	if err != nil {
+		return errors.Wrap(err, "cant exec to delete permission")
+	}
+	return nil
+}
+
+func listPermissions(ex executor, whereClause string, args []interface{}) ([]domain.Permission, error) {
+	rows, err := ex.Query(`SELECT `+permissionColumns+` FROM permissions `+whereClause+` ORDER BY credit_id, _id`, args...)
+	if err != nil {
+		return nil, errors.Wrap(err, "cant read rows from permissions")
+	}
+	defer func() {
+		if err := rows.Close(); err != nil {
+			panic(errors.Wrap(err, "cant close rows from permissions").Error())
+		}
+	}()
+	list := make([]domain.Permission, 0)
+	for rows.Next() {
+		data := domain.Permission{}
+		if err := rows.Scan(&data.Id, &data.Credit, &data.Grantor, &data.GrantedAt, &data.Channel, &data.Scope,
+			&data.Commercial, &data.Derivatives, &data.Text, &data.Proof); err != nil {
+			return nil, errors.Wrap(err, "cant read row from permissions")
+		}
+		list = append(list, data)
+	}
+	return list, nil
+}
+
+// creditsPermissions returns the permissions of every credit, in grant order.
+func creditsPermissions(ex executor) (map[int64][]domain.Permission, error) {
+	list, err := listPermissions(ex, "", nil)
+	if err != nil {
+		return nil, err
+	}
+	byCredit := make(map[int64][]domain.Permission)
+	for _, permission := range list {
+		byCredit[permission.Credit] = append(byCredit[permission.Credit], permission)
+	}
+	return byCredit, nil
+}
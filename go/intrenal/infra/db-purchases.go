@@ -0,0 +1,42 @@
+package infra
+
+import (
+	"database/sql"
+
+	"github.com/marcosbitetti/godot-manage-attribuitions-plugin/intrenal/domain"
+)
+
+// nullPurchase scans the purchase columns of a credit, they are all NULL when
+// the asset was not bought.
+type nullPurchase struct {
+	Price    sql.NullFloat64
+	Currency sql.NullString
+	Date     sql.NullString
+	OrderId  sql.NullString
+	Seats    sql.NullInt64
+	Account  sql.NullString
+}
+
+func (p nullPurchase) value() *domain.Purchase {
+	if !p.Price.Valid && !p.Currency.Valid && !p.Date.Valid &&
+		!p.OrderId.Valid && !p.Seats.Valid && !p.Account.Valid {
+		return nil
+	}
+	return &domain.Purchase{
+		Price:    p.Price.Float64,
+		Currency: p.Currency.String,
+		Date:     p.Date.String,
+		OrderId:  p.OrderId.String,
+		Seats:    int(p.Seats.Int64),
+		Account:  p.Account.String,
+	}
+}
+
+// purchaseValues returns the purchase columns to be written, in table order.
+func purchaseValues(purchase *domain.Purchase) []interface{} {
+	if purchase == nil {
+		return []interface{}{nil, nil, nil, nil, nil, nil}
+	}
+	return []interface{}{purchase.Price, purchase.Currency, purchase.Date,
+		purchase.OrderId, purchase.Seats, purchase.Account}
+}
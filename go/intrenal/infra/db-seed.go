@@ -0,0 +1,264 @@
+package infra
+
+import (
+	"database/sql"
+	"embed"
+	"encoding/json"
+	"strings"
+	"sync"
+
+	"github.com/marcosbitetti/godot-manage-attribuitions-plugin/intrenal/domain"
+	"github.com/pkg/errors"
+	"gopkg.in/yaml.v3"
+)
+
+//go:embed seeds/*.json
+var bundledSeeds embed.FS
+
+// firstSeeds are the bundled packs of the databases created without seed
+// packs.
+var firstSeeds = []string{"licences", "types", "sources"}
+
+var (
+	firstSeedPack     domain.SeedPack
+	firstSeedPackOnce sync.Once
+)
+
+// BundledSeeds lists the names of the seed packs embedded in the binary.
+func BundledSeeds() []string {
+	entries, err := bundledSeeds.ReadDir("seeds")
+	if err != nil {
+		return nil
+	}
+	names := make([]string, 0, len(entries))
+	for _, entry := range entries {
+		names = append(names, strings.TrimSuffix(entry.Name(), ".json"))
+	}
+	return names
+}
+
+// BundledSeed returns the embedded seed pack with the name, or nil when there
+// is none.
+func BundledSeed(name string) (*domain.SeedPack, error) {
+	content, err := bundledSeeds.ReadFile("seeds/" + name + ".json")
+	if err != nil {
+		return nil, nil
+	}
+	return ParseSeedPack(content, false)
+}
+
+// firstSeed merges the packs of the databases created without seed packs.
+func firstSeed() domain.SeedPack {
+	firstSeedPackOnce.Do(func() {
+		for _, name := range firstSeeds {
+			pack, err := BundledSeed(name)
+			if err != nil || pack == nil {
+				panic("invalid embedded seed pack " + name)
+			}
+			firstSeedPack.Licences = append(firstSeedPack.Licences, pack.Licences...)
+			firstSeedPack.Types = append(firstSeedPack.Types, pack.Types...)
+			firstSeedPack.Sources = append(firstSeedPack.Sources, pack.Sources...)
+			firstSeedPack.Fields = append(firstSeedPack.Fields, pack.Fields...)
+		}
+	})
+	return firstSeedPack
+}
+
+// ParseSeedPack reads a seed pack from JSON, or from YAML with the same keys.
+// Licences with a SPDX identifier are completed from the catalog and their
+// terms default to the known ones.
+func ParseSeedPack(content []byte, fromYaml bool) (*domain.SeedPack, error) {
+	if fromYaml {
+		var document interface{}
+		if err := yaml.Unmarshal(content, &document); err != nil {
+			return nil, errors.Wrap(err, "invalid seed pack")
+		}
+		converted, err := json.Marshal(document)
+		if err != nil {
+			return nil, errors.Wrap(err, "invalid seed pack")
+		}
+		content = converted
+	}
+	pack := domain.SeedPack{}
+	if err := json.Unmarshal(content, &pack); err != nil {
+		return nil, errors.Wrap(err, "invalid seed pack")
+	}
+	var raw struct {
+		Licences []json.RawMessage `json:"licences"`
+	}
+	if err := json.Unmarshal(content, &raw); err != nil {
+		return nil, errors.Wrap(err, "invalid seed pack")
+	}
+	for i := range pack.Licences {
+		licence := &pack.Licences[i]
+		if catalog := FindSpdxLicence(licence.SpdxId); licence.SpdxId != "" && catalog != nil {
+			licence.SpdxId = catalog.SpdxId
+			if licence.Name == "" {
+				licence.Name = catalog.Name
+			}
+			if licence.Link == "" {
+				licence.Link = catalog.Link
+			}
+			if licence.Text == "" {
+				licence.Text = catalog.Text
+			}
+		}
+		// the known terms are the defaults, the informed ones are read again over them
+		licence.LicenceTerms = LicenceTermsFor(licence.SpdxId, licence.Name)
+		if err := json.Unmarshal(raw.Licences[i], &licence.LicenceTerms); err != nil {
+			return nil, errors.Wrap(err, "invalid seed pack")
+		}
+	}
+	return &pack, nil
+}
+
+// ApplySeed adds the licences, types, sources and custom fields of a pack that
+// aren't registered yet. The ones already registered with the same name are
+// kept as they are, so applying a pack again changes nothing.
+func (s *Storage) ApplySeed(pack domain.SeedPack) (*domain.SeedReport, error) {
+	s.locker.Lock()
+	defer s.locker.Unlock()
+
+	var report *domain.SeedReport
+	err := s.inTransaction(func(tx *sql.Tx) error {
+		var err error
+		report, err = applySeed(tx, pack)
+		return err
+	})
+	return report, err
+}
+
+func applySeed(ex executor, pack domain.SeedPack) (*domain.SeedReport, error) {
+	report := domain.SeedReport{}
+	for _, licence := range pack.Licences {
+		found, err := seeded(ex, "licences", licence.Name)
+		if err != nil {
+			return nil, err
+		}
+		if found {
+			report.Skipped++
+			continue
+		}
+		_, err = ex.Exec(`
+			INSERT INTO licences(spdx_id, name, link, text, summary,
+				requires_attribution, allows_commercial, share_alike, allows_derivatives,
+				requires_licence_text, copyleft_scope, attribution_template, requires_permission)
+			VALUES(?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+		`, licence.SpdxId, licence.Name, licence.Link, licence.Text, licence.Summary,
+			licence.RequiresAttribution, licence.AllowsCommercial, licence.ShareAlike, licence.AllowsDerivatives,
+			licence.RequiresLicenceText, licence.CopyleftScope, licence.AttributionTemplate, licence.RequiresPermission)
+		if err != nil {
+			return nil, errors.Wrap(err, "cant seed licence")
+		}
+		report.Licences++
+	}
+
+	// the parents are linked after every type is added, a child can come first
+	children := make([]domain.SeedType, 0)
+	for _, type_ := range pack.Types {
+		found, err := seeded(ex, "types", type_.Name)
+		if err != nil {
+			return nil, err
+		}
+		if found {
+			report.Skipped++
+			continue
+		}
+		licence, err := seedLicence(ex, type_.DefaultLicence)
+		if err != nil {
+			return nil, err
+		}
+		requires := type_.Requires
+		if requires == nil {
+			requires = domain.DefaultRequirements
+		}
+		_, err = ex.Exec(`INSERT INTO types(name, default_licence_id, requires) VALUES(?, ?, ?)`,
+			type_.Name, licence, joinList(requires))
+		if err != nil {
+			return nil, errors.Wrap(err, "cant seed type")
+		}
+		report.Types++
+		if type_.Parent != "" {
+			children = append(children, type_)
+		}
+	}
+	for _, type_ := range children {
+		var parent int64
+		err := ex.QueryRow(`SELECT _id FROM types WHERE name = ? COLLATE NOCASE`, type_.Parent).Scan(&parent)
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, errors.Errorf("unknown parent type %q in seed pack", type_.Parent)
+		}
+		if err != nil {
+			return nil, errors.Wrap(err, "cant read parent type")
+		}
+		if _, err := ex.Exec(`UPDATE types SET parent_id=? WHERE name=?`, parent, type_.Name); err != nil {
+			return nil, errors.Wrap(err, "cant seed parent type")
+		}
+	}
+
+	for _, source := range pack.Sources {
+		found, err := seeded(ex, "sources", source.Name)
+		if err != nil {
+			return nil, err
+		}
+		if found {
+			report.Skipped++
+			continue
+		}
+		licence, err := seedLicence(ex, source.DefaultLicence)
+		if err != nil {
+			return nil, err
+		}
+		_, err = ex.Exec(`INSERT INTO sources(name, base_url, default_licence_id) VALUES(?, ?, ?)`,
+			source.Name, source.BaseUrl, licence)
+		if err != nil {
+			return nil, errors.Wrap(err, "cant seed source")
+		}
+		report.Sources++
+	}
+
+	for _, field := range pack.Fields {
+		found, err := seeded(ex, "field_definitions", field.Name)
+		if err != nil {
+			return nil, err
+		}
+		if found {
+			report.Skipped++
+			continue
+		}
+		_, err = ex.Exec(`INSERT INTO field_definitions(name, type, required, options) VALUES(?, ?, ?, ?)`,
+			field.Name, field.Type, field.Required, joinList(field.Options))
+		if err != nil {
+			return nil, errors.Wrap(err, "cant seed field")
+		}
+		report.Fields++
+	}
+	return &report, nil
+}
+
+// seeded tells if the table already has a row with the name, ignoring case.
+func seeded(ex executor, table string, name string) (bool, error) {
+	var found bool
+	err := ex.QueryRow(`SELECT EXISTS(SELECT 1 FROM `+table+` WHERE name = ? COLLATE NOCASE)`, name).Scan(&found)
+	if err != nil {
+		return false, errors.Wrap(err, "cant read "+table)
+	}
+	return found, nil
+}
+
+// seedLicence returns the _id of the licence named by a pack, null without a
+// name.
+func seedLicence(ex executor, name string) (sql.NullInt64, error) {
+	var id sql.NullInt64
+	if name == "" {
+		return id, nil
+	}
+	err := ex.QueryRow(`SELECT _id FROM licences WHERE name = ?`, name).Scan(&id)
+	if errors.Is(err, sql.ErrNoRows) {
+		return id, errors.Errorf("unknown licence %q in seed pack", name)
+	}
+	if err != nil {
+		return id, errors.Wrap(err, "cant read licence")
+	}
+	return id, nil
+}
@@ -0,0 +1,156 @@
+package infra
+
+import (
+	"database/sql"
+	"net/url"
+	"strings"
+
+	"github.com/marcosbitetti/godot-manage-attribuitions-plugin/intrenal/domain"
+	"github.com/pkg/errors"
+)
+
+func (s *Storage) ListSources() ([]domain.Source, error) {
+	s.locker.Lock()
+	defer s.locker.Unlock()
+
+	return listSources(s.ex, "", nil)
+}
+
+// GetSource returns a source by id, or nil when missing.
+func (s *Storage) GetSource(id int64) (*domain.Source, error) {
+	s.locker.Lock()
+	defer s.locker.Unlock()
+
+	list, err := listSources(s.ex, `WHERE s._id = ?`, []interface{}{id})
+	if err != nil || len(list) == 0 {
+		return nil, err
+	}
+	return &list[0], nil
+}
+
+func (s *Storage) AddSource(source domain.Source) (int64, error) {
+	s.locker.Lock()
+	defer s.locker.Unlock()
+
+	stmt, err := s.ex.Prepare(`
+		INSERT INTO sources(name, base_url, default_licence_id)
+		VALUES(?, ?, (SELECT _id FROM licences WHERE name=?))
+	`)
+	if err != nil {
+		return 0, errors.Wrap(err, "cant prepare to add source")
+	}
+	defer func() {
+		if err := stmt.Close(); err != nil {
+			panic(errors.Wrap(err, "cant close prepare to add source").Error())
+		}
+	}()
+	result, err := stmt.Exec(source.Name, source.BaseUrl, source.DefaultLicence)
+	if err != nil {
+		return 0, errors.Wrap(err, "cant exec to add source")
+	}
+	return result.LastInsertId()
+}
+
+func (s *Storage) UpdateSource(source domain.Source) error {
+	s.locker.Lock()
+	defer s.locker.Unlock()
+
+	stmt, err := s.ex.Prepare(`
+		UPDATE sources SET name=?, base_url=?, default_licence_id=(SELECT _id FROM licences WHERE name=?)
+		WHERE _id=?
+	`)
+	if err != nil {
+		return errors.Wrap(err, "cant prepare to update source")
+	}
+	defer func() {
+		if err := stmt.Close(); err != nil {
+			panic(errors.Wrap(err, "cant close prepare to update source").Error())
+		}
+	}()
+	_, err = stmt.Exec(source.Name, source.BaseUrl, source.DefaultLicence, source.Id)
+	if err != nil {
+		return errors.Wrap(err, "cant exec to update source")
+	}
+	return nil
+}
+
+// DeleteSource deletes a source, its credits are left without source.
+func (s *Storage) DeleteSource(id int64) error {
+	s.locker.Lock()
+	defer s.locker.Unlock()
+
+	return s.inTransaction(func(tx *sql.Tx) error {
+		if _, err := tx.Exec(`UPDATE credits SET source_id=NULL WHERE source_id = ?`, id); err != nil {
+			return errors.Wrap(err, "cant exec to unlink source")
+		}
+		if _, err := tx.Exec(`DELETE FROM sources WHERE _id = ?`, id); err != nil {
+			return errors.Wrap(err, "cant exec to delete source")
+		}
+		return nil
+	})
+}
+
+func listSources(ex executor, whereClause string, args []interface{}) ([]domain.Source, error) {
+	list := make([]domain.Source, 0)
+	rows, err := ex.Query(`
+		SELECT s._id, s.name, s.base_url, COALESCE(l.name, ''),
+			(SELECT COUNT(*) FROM credits c WHERE c.source_id = s._id)
+		FROM sources s
+		LEFT JOIN licences l ON l._id = s.default_licence_id
+		`+whereClause+`
+		ORDER BY s.name COLLATE NOCASE ASC
+	`, args...)
+	if err != nil {
+		return nil, errors.Wrap(err, "cant read rows from sources")
+	}
+	defer func() {
+		if err := rows.Close(); err != nil {
+			panic(errors.Wrap(err, "cant close rows from sources").Error())
+		}
+	}()
+	for rows.Next() {
+		data := domain.Source{}
+		if err := rows.Scan(&data.Id, &data.Name, &data.BaseUrl, &data.DefaultLicence, &data.Credits); err != nil {
+			return nil, errors.Wrap(err, "cant read row from sources")
+		}
+		list = append(list, data)
+	}
+	return list, nil
+}
+
+// DetectSource returns the source of a link, comparing its host and path with
+// the base URLs. Subdomains match, like author.itch.io, and the longest base
+// URL wins. It returns nil for links of unknown sites.
+func DetectSource(sources []domain.Source, link string) *domain.Source {
+	host, path := splitUrl(link)
+	if host == "" {
+		return nil
+	}
+	var found *domain.Source
+	longest := -1
+	for i, source := range sources {
+		baseHost, basePath := splitUrl(source.BaseUrl)
+		if baseHost == "" || (host != baseHost && !strings.HasSuffix(host, "."+baseHost)) {
+			continue
+		}
+		if basePath != "" && path != basePath && !strings.HasPrefix(path, basePath+"/") {
+			continue
+		}
+		if length := len(baseHost) + len(basePath); length > longest {
+			longest = length
+			found = &sources[i]
+		}
+	}
+	return found
+}
+
+// splitUrl returns the host without "www." and the path without the trailing
+// slash of an URL, or an empty host when it isn't one.
+func splitUrl(link string) (string, string) {
+	parsed, err := url.Parse(strings.TrimSpace(link))
+	if err != nil || parsed.Hostname() == "" {
+		return "", ""
+	}
+	host := strings.TrimPrefix(strings.ToLower(parsed.Hostname()), "www.")
+	return host, strings.TrimSuffix(parsed.Path, "/")
+}
@@ -0,0 +1,115 @@
+package infra
+
+import (
+	"database/sql"
+
+	"github.com/pkg/errors"
+
+	"github.com/marcosbitetti/godot-manage-attribuitions-plugin/intrenal/domain"
+)
+
+// taggedCredits selects the credits linked to tags, the caller completes the
+// condition on the tag name. Tag names ignore case.
+const taggedCredits = `
+	SELECT ct.credit_id FROM credit_tags ct
+	JOIN tags g ON g._id = ct.tag_id
+	WHERE g.name`
+
+// ListTags returns every tag with the number of credits using it.
+func (s *Storage) ListTags() ([]domain.Tag, error) {
+	s.locker.Lock()
+	defer s.locker.Unlock()
+
+	rows, err := s.ex.Query(`
+		SELECT g._id, g.name, COUNT(ct.credit_id)
+		FROM tags g
+		LEFT JOIN credit_tags ct ON ct.tag_id = g._id
+		GROUP BY g._id
+		ORDER BY g.name COLLATE NOCASE ASC
+	`)
+	if err != nil {
+		return nil, errors.Wrap(err, "cant read rows from tags")
+	}
+	defer func() {
+		if err := rows.Close(); err != nil {
+			panic(errors.Wrap(err, "cant close rows from tags").Error())
+		}
+	}()
+	list := make([]domain.Tag, 0)
+	for rows.Next() {
+		data := domain.Tag{}
+		if err := rows.Scan(&data.Id, &data.Name, &data.Credits); err != nil {
+			return nil, errors.Wrap(err, "cant read row from tags")
+		}
+		list = append(list, data)
+	}
+	return list, nil
+}
+
+// TagCredit adds tags to a credit, creating the tags missing.
+func (s *Storage) TagCredit(credit int64, tags []string) error {
+	s.locker.Lock()
+	defer s.locker.Unlock()
+
+	return s.inTransaction(func(tx *sql.Tx) error {
+		for _, tag := range tags {
+			if _, err := tx.Exec(`INSERT OR IGNORE INTO tags(name) VALUES(?)`, tag); err != nil {
+				return errors.Wrap(err, "cant exec to add tag")
+			}
+			_, err := tx.Exec(`
+				INSERT OR IGNORE INTO credit_tags(credit_id, tag_id)
+				VALUES(?, (SELECT _id FROM tags WHERE name = ?))
+			`, credit, tag)
+			if err != nil {
+				return errors.Wrap(err, "cant exec to tag credit")
+			}
+		}
+		return nil
+	})
+}
+
+// UntagCredit removes tags from a credit, the tags are kept.
+func (s *Storage) UntagCredit(credit int64, tags []string) error {
+	s.locker.Lock()
+	defer s.locker.Unlock()
+
+	return s.inTransaction(func(tx *sql.Tx) error {
+		for _, tag := range tags {
+			_, err := tx.Exec(`
+				DELETE FROM credit_tags
+				WHERE credit_id = ? AND tag_id = (SELECT _id FROM tags WHERE name = ?)
+			`, credit, tag)
+			if err != nil {
+				return errors.Wrap(err, "cant exec to untag credit")
+			}
+		}
+		return nil
+	})
+}
+
+// creditsTags returns the tag names of every credit, in name order.
+func creditsTags(ex executor) (map[int64][]string, error) {
+	rows, err := ex.Query(`
+		SELECT ct.credit_id, g.name FROM credit_tags ct
+		JOIN tags g ON g._id = ct.tag_id
+		ORDER BY ct.credit_id, g.name COLLATE NOCASE
+	`)
+	if err != nil {
+		return nil, errors.Wrap(err, "cant read tags of credits")
+	}
+	defer func() {
+		if err := rows.Close(); err != nil {
+			panic(errors.Wrap(err, "cant close tags of credits").Error())
+		}
+	}()
+	byCredit := make(map[int64][]string)
+	for rows.Next() {
+		var credit int64
+		var name string
+		if err := rows.Scan(&credit, &name); err != nil {
+			return nil, errors.Wrap(err, "cant read tag of credit")
+		}
+		byCredit[credit] = append(byCredit[credit], name)
+	}
+	return byCredit, nil
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"database/sql"
 	"fmt"
+	"io"
 	"os"
 	"strings"
 	"sync"
@@ -19,28 +20,87 @@ const AtribuitionModelPath = "ATRIBUITION_HANDLER_PATH"
 
 type StorageInterface interface {
 	CloseDatabase()
-	AddType(name string) error
-	UpdateType(id int64, name string) error
+	AddType(t domain.Type) (int64, error)
+	UpdateType(t domain.Type) error
 	DeleteType(id int64) error
+	MergeTypes(into int64, from []int64) (int64, error)
 	ListTypes() ([]domain.Type, error)
-	AddLicence(name string, link string) error
-	UpdateLicence(id int64, name string, link string) error
+	GetType(id int64) (*domain.Type, error)
+	FindType(name string) (*domain.Type, error)
+	AddLicence(licence domain.Licence) (int64, error)
+	UpdateLicence(licence domain.Licence) error
 	DeleteLicence(id int64) error
+	MergeLicences(into int64, from []int64) (int64, error)
 	ListLicences() ([]domain.Licence, error)
-	AddAttribuition(name string, fileame string, author string, link string, ctype string, licence string) error
-	FindAttribuitions(ascDesc string, search string) ([]domain.Attribuition, error)
-	UpdateAttribuition(id int64, name string, fileame string, author string, link string, ctype string, licence string) error
+	GetLicence(id int64) (*domain.Licence, error)
+	FindLicence(name string) (*domain.Licence, error)
+	AddAttribuition(attribuition domain.Attribuition) (int64, error)
+	FindAttribuitions(query domain.Query) ([]domain.Attribuition, error)
+	GetAttribuition(id int64) (*domain.Attribuition, error)
+	FindAttribuitionByKey(key string, attribuition domain.Attribuition) (*domain.Attribuition, error)
+	UpdateAttribuition(attribuition domain.Attribuition) error
 	DeleteAttribuition(id int64) error
+	ListAuthors() ([]domain.Author, error)
+	GetAuthor(id int64) (*domain.Author, error)
+	FindAuthor(name string) (*domain.Author, error)
+	AddAuthor(author domain.Author) (int64, error)
+	UpdateAuthor(author domain.Author) error
+	DeleteAuthor(id int64) error
+	MergeAuthors(into int64, from []int64) (int64, error)
+	AddLink(credit int64, link domain.Link) (int64, error)
+	RemoveLink(id int64) error
+	ListSources() ([]domain.Source, error)
+	GetSource(id int64) (*domain.Source, error)
+	AddSource(source domain.Source) (int64, error)
+	UpdateSource(source domain.Source) error
+	DeleteSource(id int64) error
+	ListPermissions(credit int64) ([]domain.Permission, error)
+	GetPermission(id int64) (*domain.Permission, error)
+	AddPermission(permission domain.Permission) (int64, error)
+	UpdatePermission(permission domain.Permission) error
+	DeletePermission(id int64) error
+	ListAttachments(credit int64) ([]domain.Attachment, error)
+	GetAttachment(id int64) (*domain.Attachment, error)
+	AddAttachment(attachment domain.Attachment, data []byte) (int64, error)
+	CopyAttachment(id int64, w io.Writer) error
+	RemoveAttachment(id int64) error
+	ListTags() ([]domain.Tag, error)
+	TagCredit(credit int64, tags []string) error
+	UntagCredit(credit int64, tags []string) error
+	ListFields() ([]domain.FieldDefinition, error)
+	GetField(id int64) (*domain.FieldDefinition, error)
+	AddField(field domain.FieldDefinition) (int64, error)
+	UpdateField(field domain.FieldDefinition) error
+	DeleteField(id int64) error
+	FieldValues(id int64) (int, error)
+	GetProfile() (domain.ProjectProfile, error)
+	UpdateProfile(profile domain.ProjectProfile) error
+	ApplySeed(pack domain.SeedPack) (*domain.SeedReport, error)
+	Batch(fn func(batch *Storage) error) error
+}
+
+// executor runs statements over the database or inside a transaction.
+type executor interface {
+	Prepare(query string) (*sql.Stmt, error)
+	Exec(query string, args ...interface{}) (sql.Result, error)
+	Query(query string, args ...interface{}) (*sql.Rows, error)
+	QueryRow(query string, args ...interface{}) *sql.Row
 }
 
 type Storage struct {
-	db     *sql.DB
+	db *sql.DB
+	// ex runs the statements, the database or the transaction of a batch.
+	ex     executor
+	tx     *sql.Tx
 	locker sync.Mutex
 }
 
 var _ StorageInterface = &Storage{db: nil}
 
-func NewStorage(path string) (*Storage, error) {
+// NewStorage opens the database at path, creating it when missing. A new
+// database starts with the seed packs, or with the bundled first packs when
+// none is given.
+func NewStorage(path string, seeds ...domain.SeedPack) (*Storage, error) {
 	var err error
 	var needToInit = false
 	_, err = os.Stat(path)
@@ -65,10 +125,21 @@ func NewStorage(path string) (*Storage, error) {
 
 	storage := &Storage{
 		db: db,
+		ex: db,
 	}
 
 	if needToInit {
-		initDatabase(storage)
+		err = initDatabase(storage, seeds)
+		if err != nil {
+			// a database left half built would never be seeded again
+			db.Close()
+			os.Remove(path)
+		}
+	} else {
+		err = upgradeDatabase(storage)
+	}
+	if err != nil {
+		return nil, err
 	}
 	return storage, nil
 }
@@ -91,23 +162,80 @@ func ParseDatabasePath(args []string) (string, error) {
 	return file, nil
 }
 
-func initDatabase(storage *Storage) {
+func initDatabase(storage *Storage, seeds []domain.SeedPack) error {
 	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
 	defer cancel()
 	createBaseTable(ctx, storage)
-	dumpFirstTypes(storage)
-	dumpFirstLicences(storage)
+	if err := migrateDatabase(ctx, storage); err != nil {
+		return err
+	}
+	if len(seeds) == 0 {
+		seeds = []domain.SeedPack{firstSeed()}
+	}
+	return storage.inTransaction(func(tx *sql.Tx) error {
+		for _, pack := range seeds {
+			if _, err := applySeed(tx, pack); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
 }
 
-func (s *Storage) AddType(name string) error {
+func upgradeDatabase(storage *Storage) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	return migrateDatabase(ctx, storage)
+}
+
+// inTransaction runs fn in a transaction, rolled back when fn fails. Inside a
+// batch fn runs in the transaction of the batch.
+func (s *Storage) inTransaction(fn func(tx *sql.Tx) error) error {
+	if s.tx != nil {
+		return fn(s.tx)
+	}
+	tx, err := s.db.Begin()
+	if err != nil {
+		return errors.Wrap(err, "cant begin transaction")
+	}
+	if err := fn(tx); err != nil {
+		tx.Rollback()
+		return err
+	}
+	if err := tx.Commit(); err != nil {
+		return errors.Wrap(err, "cant commit transaction")
+	}
+	return nil
+}
+
+// listSeparator joins the values of a list stored in one column, like the
+// aliases of an author or the requirements of a type.
+const listSeparator = "\n"
+
+func joinList(values []string) string {
+	return strings.Join(values, listSeparator)
+}
+
+func splitList(value string) []string {
+	list := make([]string, 0)
+	for _, item := range strings.Split(value, listSeparator) {
+		if item = strings.TrimSpace(item); item != "" {
+			list = append(list, item)
+		}
+	}
+	return list
+}
+
+func (s *Storage) AddType(t domain.Type) (int64, error) {
 	s.locker.Lock()
 	defer s.locker.Unlock()
 
-	stmt, err := s.db.Prepare(`
-		INSERT INTO types(name) VALUES(?);
+	stmt, err := s.ex.Prepare(`
+		INSERT INTO types(name, parent_id, default_licence_id, requires)
+		VALUES(?, NULLIF(?, 0), (SELECT _id FROM licences WHERE name=?), ?);
 	`)
 	if err != nil {
-		return errors.Wrap(err, "cant prepare to add type")
+		return 0, errors.Wrap(err, "cant prepare to add type")
 	}
 
 	defer func() {
@@ -116,19 +244,24 @@ func (s *Storage) AddType(name string) error {
 		}
 	}()
 
-	_, err = stmt.Exec(name)
+	result, err := stmt.Exec(t.Name, t.Parent, t.DefaultLicence, joinList(t.Requires))
 	if err != nil {
-		return errors.Wrap(err, "cant exec to add type")
+		return 0, errors.Wrap(err, "cant exec to add type")
 	}
-	return nil
+	return result.LastInsertId()
 }
 
-func (s *Storage) UpdateType(id int64, name string) error {
+func (s *Storage) UpdateType(t domain.Type) error {
 	s.locker.Lock()
 	defer s.locker.Unlock()
 
-	stmt, err := s.db.Prepare(`
-		UPDATE types SET name=? WHERE _id=?
+	stmt, err := s.ex.Prepare(`
+		UPDATE types SET
+			name=?,
+			parent_id=NULLIF(?, 0),
+			default_licence_id=(SELECT _id FROM licences WHERE name=?),
+			requires=?
+		WHERE _id=?
 	`)
 	if err != nil {
 		return errors.Wrap(err, "cant prepare to update type")
@@ -140,41 +273,125 @@ func (s *Storage) UpdateType(id int64, name string) error {
 		}
 	}()
 
-	_, err = stmt.Exec(name, id)
+	_, err = stmt.Exec(t.Name, t.Parent, t.DefaultLicence, joinList(t.Requires), t.Id)
 	if err != nil {
 		return errors.Wrap(err, "cant exec to update type")
 	}
 	return nil
 }
 
+// DeleteType removes a type, its children and attribuitions move up to its
+// parent. The attribuitions of a top level type are kept as they are.
 func (s *Storage) DeleteType(id int64) error {
 	s.locker.Lock()
 	defer s.locker.Unlock()
 
-	stmt, err := s.db.Prepare(`DELETE FROM types WHERE _id = ?`)
-	if err != nil {
-		return errors.Wrap(err, "cant prepare to delete type")
-	}
-	defer func() {
-		if err := stmt.Close(); err != nil {
-			panic(errors.Wrap(err, "cant close prepare to delete type").Error())
+	return s.inTransaction(func(tx *sql.Tx) error {
+		var parent sql.NullInt64
+		err := tx.QueryRow(`SELECT parent_id FROM types WHERE _id = ?`, id).Scan(&parent)
+		if err == sql.ErrNoRows {
+			return nil
 		}
-	}()
-	_, err = stmt.Exec(id)
+		if err != nil {
+			return errors.Wrap(err, "cant read type to delete")
+		}
+		if _, err := tx.Exec(`UPDATE types SET parent_id = ? WHERE parent_id = ?`, parent, id); err != nil {
+			return errors.Wrap(err, "cant move children of type")
+		}
+		if parent.Valid {
+			if _, err := tx.Exec(`UPDATE credits SET type_id = ? WHERE type_id = ?`, parent, id); err != nil {
+				return errors.Wrap(err, "cant move attribuitions of type")
+			}
+		}
+		if _, err := tx.Exec(`DELETE FROM types WHERE _id = ?`, id); err != nil {
+			return errors.Wrap(err, "cant exec to delete type")
+		}
+		return nil
+	})
+}
+
+// MergeTypes moves the credits and the subtypes of the sources to the target
+// type and deletes the sources. It returns how many credits were moved.
+func (s *Storage) MergeTypes(into int64, from []int64) (int64, error) {
+	s.locker.Lock()
+	defer s.locker.Unlock()
+
+	var moved int64
+	err := s.inTransaction(func(tx *sql.Tx) error {
+		if err := mustExist(tx, "types", into); err != nil {
+			return err
+		}
+		for _, id := range from {
+			if id == into {
+				continue
+			}
+			if err := mustExist(tx, "types", id); err != nil {
+				return err
+			}
+			result, err := tx.Exec(`UPDATE credits SET type_id = ? WHERE type_id = ?`, into, id)
+			if err != nil {
+				return errors.Wrap(err, "cant move attribuitions of type")
+			}
+			count, err := result.RowsAffected()
+			if err != nil {
+				return errors.Wrap(err, "cant count attribuitions of type")
+			}
+			moved += count
+			if _, err := tx.Exec(`UPDATE types SET parent_id = ? WHERE parent_id = ?`, into, id); err != nil {
+				return errors.Wrap(err, "cant move children of type")
+			}
+			if _, err := tx.Exec(`DELETE FROM types WHERE _id = ?`, id); err != nil {
+				return errors.Wrap(err, "cant exec to delete type")
+			}
+		}
+		return nil
+	})
 	if err != nil {
-		return errors.Wrap(err, "cant exec to delete type")
+		return 0, err
 	}
-	return nil
+	return moved, nil
 }
 
 func (s *Storage) ListTypes() ([]domain.Type, error) {
 	s.locker.Lock()
 	defer s.locker.Unlock()
 
+	return listTypes(s.ex, "", nil)
+}
+
+// GetType returns nil when there is no type with the id.
+func (s *Storage) GetType(id int64) (*domain.Type, error) {
+	s.locker.Lock()
+	defer s.locker.Unlock()
+
+	list, err := listTypes(s.ex, `WHERE t._id = ?`, []interface{}{id})
+	if err != nil || len(list) == 0 {
+		return nil, err
+	}
+	return &list[0], nil
+}
+
+// FindType returns the type with the name, ignoring case, or nil when missing.
+func (s *Storage) FindType(name string) (*domain.Type, error) {
+	s.locker.Lock()
+	defer s.locker.Unlock()
+
+	list, err := listTypes(s.ex, `WHERE t.name = ? COLLATE NOCASE`, []interface{}{name})
+	if err != nil || len(list) == 0 {
+		return nil, err
+	}
+	return &list[0], nil
+}
+
+func listTypes(ex executor, whereClause string, args []interface{}) ([]domain.Type, error) {
 	list := make([]domain.Type, 0)
-	rows, err := s.db.Query(`
-		SELECT _id, name FROM types ORDER BY name COLLATE NOCASE ASC
-	`)
+	rows, err := ex.Query(`
+		SELECT t._id, t.name, COALESCE(t.parent_id, 0), COALESCE(l.name, ''), t.requires
+		FROM types t
+		LEFT JOIN licences l ON l._id = t.default_licence_id
+		`+whereClause+`
+		ORDER BY t.name COLLATE NOCASE ASC
+	`, args...)
 	if err != nil {
 		return nil, errors.Wrap(err, "cant read rows from types")
 	}
@@ -185,23 +402,28 @@ func (s *Storage) ListTypes() ([]domain.Type, error) {
 	}()
 	for rows.Next() {
 		data := domain.Type{}
-		if err := rows.Scan(&data.Id, &data.Name); err != nil {
+		var requires string
+		if err := rows.Scan(&data.Id, &data.Name, &data.Parent, &data.DefaultLicence, &requires); err != nil {
 			return nil, errors.Wrap(err, "cant read row from types")
 		}
+		data.Requires = splitList(requires)
 		list = append(list, data)
 	}
 	return list, nil
 }
 
-func (s *Storage) AddLicence(name string, link string) error {
+func (s *Storage) AddLicence(licence domain.Licence) (int64, error) {
 	s.locker.Lock()
 	defer s.locker.Unlock()
 
-	stmt, err := s.db.Prepare(`
-		INSERT INTO licences(name, link) VALUES(?, ?);
+	stmt, err := s.ex.Prepare(`
+		INSERT INTO licences(spdx_id, name, link, text, summary,
+			requires_attribution, allows_commercial, share_alike, allows_derivatives,
+			requires_licence_text, copyleft_scope, attribution_template, requires_permission)
+		VALUES(?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?);
 	`)
 	if err != nil {
-		return errors.Wrap(err, "cant prepare to add licence")
+		return 0, errors.Wrap(err, "cant prepare to add licence")
 	}
 
 	defer func() {
@@ -210,19 +432,24 @@ func (s *Storage) AddLicence(name string, link string) error {
 		}
 	}()
 
-	_, err = stmt.Exec(name, link)
+	result, err := stmt.Exec(licence.SpdxId, licence.Name, licence.Link, licence.Text, licence.Summary,
+		licence.RequiresAttribution, licence.AllowsCommercial, licence.ShareAlike, licence.AllowsDerivatives,
+		licence.RequiresLicenceText, licence.CopyleftScope, licence.AttributionTemplate, licence.RequiresPermission)
 	if err != nil {
-		return errors.Wrap(err, "cant exec to add Licence")
+		return 0, errors.Wrap(err, "cant exec to add Licence")
 	}
-	return nil
+	return result.LastInsertId()
 }
 
-func (s *Storage) UpdateLicence(id int64, name string, link string) error {
+func (s *Storage) UpdateLicence(licence domain.Licence) error {
 	s.locker.Lock()
 	defer s.locker.Unlock()
 
-	stmt, err := s.db.Prepare(`
-		UPDATE licences SET name=?, link=? WHERE _id=?
+	stmt, err := s.ex.Prepare(`
+		UPDATE licences SET spdx_id=?, name=?, link=?, text=?, summary=?,
+			requires_attribution=?, allows_commercial=?, share_alike=?, allows_derivatives=?,
+			requires_licence_text=?, copyleft_scope=?, attribution_template=?, requires_permission=?
+		WHERE _id=?
 	`)
 	if err != nil {
 		return errors.Wrap(err, "cant prepare to update licence")
@@ -234,7 +461,10 @@ func (s *Storage) UpdateLicence(id int64, name string, link string) error {
 		}
 	}()
 
-	_, err = stmt.Exec(name, link, id)
+	_, err = stmt.Exec(licence.SpdxId, licence.Name, licence.Link, licence.Text, licence.Summary,
+		licence.RequiresAttribution, licence.AllowsCommercial, licence.ShareAlike, licence.AllowsDerivatives,
+		licence.RequiresLicenceText, licence.CopyleftScope, licence.AttributionTemplate,
+		licence.RequiresPermission, licence.Id)
 	if err != nil {
 		return errors.Wrap(err, "cant exec to update licence")
 	}
@@ -245,7 +475,7 @@ func (s *Storage) DeleteLicence(id int64) error {
 	s.locker.Lock()
 	defer s.locker.Unlock()
 
-	stmt, err := s.db.Prepare(`DELETE FROM licences WHERE _id = ?`)
+	stmt, err := s.ex.Prepare(`DELETE FROM licences WHERE _id = ?`)
 	if err != nil {
 		return errors.Wrap(err, "cant prepare to delete licence")
 	}
@@ -261,13 +491,87 @@ func (s *Storage) DeleteLicence(id int64) error {
 	return nil
 }
 
+// MergeLicences moves the credits of the sources to the target licence, as the
+// default licence of types and sources too, and deletes the sources. The
+// target keeps its data, filling what it misses from the sources. It returns
+// how many credits were moved.
+func (s *Storage) MergeLicences(into int64, from []int64) (int64, error) {
+	s.locker.Lock()
+	defer s.locker.Unlock()
+
+	var moved int64
+	err := s.inTransaction(func(tx *sql.Tx) error {
+		if err := mustExist(tx, "licences", into); err != nil {
+			return err
+		}
+		for _, id := range from {
+			if id == into {
+				continue
+			}
+			if err := mustExist(tx, "licences", id); err != nil {
+				return err
+			}
+			result, err := tx.Exec(`UPDATE credits SET licence_id = ? WHERE licence_id = ?`, into, id)
+			if err != nil {
+				return errors.Wrap(err, "cant move attribuitions of licence")
+			}
+			count, err := result.RowsAffected()
+			if err != nil {
+				return errors.Wrap(err, "cant count attribuitions of licence")
+			}
+			moved += count
+			if _, err := tx.Exec(`UPDATE types SET default_licence_id = ? WHERE default_licence_id = ?`, into, id); err != nil {
+				return errors.Wrap(err, "cant move types of licence")
+			}
+			if _, err := tx.Exec(`UPDATE sources SET default_licence_id = ? WHERE default_licence_id = ?`, into, id); err != nil {
+				return errors.Wrap(err, "cant move sources of licence")
+			}
+			_, err = tx.Exec(`
+				UPDATE licences SET
+					spdx_id = CASE WHEN target.spdx_id = '' THEN source.spdx_id ELSE target.spdx_id END,
+					link = CASE WHEN target.link = '' THEN source.link ELSE target.link END,
+					text = CASE WHEN target.text = '' THEN source.text ELSE target.text END,
+					summary = CASE WHEN target.summary = '' THEN source.summary ELSE target.summary END
+				FROM licences target, licences source
+				WHERE licences._id = target._id AND target._id = ? AND source._id = ?
+			`, into, id)
+			if err != nil {
+				return errors.Wrap(err, "cant exec to update licence")
+			}
+			if _, err := tx.Exec(`DELETE FROM licences WHERE _id = ?`, id); err != nil {
+				return errors.Wrap(err, "cant exec to delete licence")
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return 0, err
+	}
+	return moved, nil
+}
+
+// mustExist fails when the table has no row with the id.
+func mustExist(ex executor, table string, id int64) error {
+	var found bool
+	if err := ex.QueryRow(`SELECT EXISTS(SELECT 1 FROM `+table+` WHERE _id = ?)`, id).Scan(&found); err != nil {
+		return errors.Wrap(err, "cant read "+table)
+	}
+	if !found {
+		return errors.New("not found in " + table)
+	}
+	return nil
+}
+
 func (s *Storage) ListLicences() ([]domain.Licence, error) {
 	s.locker.Lock()
 	defer s.locker.Unlock()
 
 	list := make([]domain.Licence, 0)
-	rows, err := s.db.Query(`
-		SELECT _id, name, link FROM licences ORDER BY name COLLATE NOCASE ASC
+	rows, err := s.ex.Query(`
+		SELECT _id, spdx_id, name, link, summary,
+			requires_attribution, allows_commercial, share_alike, allows_derivatives,
+			requires_licence_text, copyleft_scope, attribution_template, requires_permission
+		FROM licences ORDER BY name COLLATE NOCASE ASC
 	`)
 	if err != nil {
 		return nil, errors.Wrap(err, "cant read rows from licences")
@@ -279,7 +583,10 @@ func (s *Storage) ListLicences() ([]domain.Licence, error) {
 	}()
 	for rows.Next() {
 		data := domain.Licence{}
-		if err := rows.Scan(&data.Id, &data.Name, &data.Link); err != nil {
+		if err := rows.Scan(&data.Id, &data.SpdxId, &data.Name, &data.Link, &data.Summary,
+			&data.RequiresAttribution, &data.AllowsCommercial, &data.ShareAlike, &data.AllowsDerivatives,
+			&data.RequiresLicenceText, &data.CopyleftScope, &data.AttributionTemplate,
+			&data.RequiresPermission); err != nil {
 			return nil, errors.Wrap(err, "cant read row from licences")
 		}
 		list = append(list, data)
@@ -287,53 +594,149 @@ func (s *Storage) ListLicences() ([]domain.Licence, error) {
 	return list, nil
 }
 
-func (s *Storage) AddAttribuition(name string, fileame string, author string, link string, ctype string, licence string) error {
+// GetLicence returns a licence including its full text, or nil when missing.
+func (s *Storage) GetLicence(id int64) (*domain.Licence, error) {
+	return s.getLicence(`_id = ?`, id)
+}
+
+// FindLicence returns the licence with the given name including its full text,
+// or nil when missing.
+func (s *Storage) FindLicence(name string) (*domain.Licence, error) {
+	return s.getLicence(`name = ?`, name)
+}
+
+func (s *Storage) getLicence(where string, arg interface{}) (*domain.Licence, error) {
 	s.locker.Lock()
 	defer s.locker.Unlock()
 
-	stmt, err := s.db.Prepare(`
-		INSERT InTO credits
-		(name, filename, author, link, type_id, licence_id)
-		VALUES
-		(?, ?, ?, ?,
-			(SELECT _id FROM types WHERE name=?),
-			(SELECT _id FROM licences WHERE name=?)
-		)
-	`)
+	data := domain.Licence{}
+	err := s.ex.QueryRow(`
+		SELECT _id, spdx_id, name, link, text, summary,
+			requires_attribution, allows_commercial, share_alike, allows_derivatives,
+			requires_licence_text, copyleft_scope, attribution_template, requires_permission
+		FROM licences WHERE `+where, arg,
+	).Scan(&data.Id, &data.SpdxId, &data.Name, &data.Link, &data.Text, &data.Summary,
+		&data.RequiresAttribution, &data.AllowsCommercial, &data.ShareAlike, &data.AllowsDerivatives,
+		&data.RequiresLicenceText, &data.CopyleftScope, &data.AttributionTemplate, &data.RequiresPermission)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	}
 	if err != nil {
-		return errors.Wrap(err, "cant prepare to add attribuition")
+		return nil, errors.Wrap(err, "cant read licence")
 	}
-	defer func() {
-		if err := stmt.Close(); err != nil {
-			panic(errors.Wrap(err, "cant close prepare to add attribuition").Error())
+	return &data, nil
+}
+
+// AddAttribuition stores a credit with its authors and returns its id.
+func (s *Storage) AddAttribuition(attribuition domain.Attribuition) (int64, error) {
+	s.locker.Lock()
+	defer s.locker.Unlock()
+
+	var id int64
+	err := s.inTransaction(func(tx *sql.Tx) error {
+		result, err := tx.Exec(`
+			INSERT InTO credits
+			(name, filename, author, link, attribution_override,
+				modified, modification_notes, upstream_link, type_id, licence_id, source_id,
+				purchase_price, purchase_currency, purchase_date, purchase_order, purchase_seats, purchase_account,
+				valid_until, renew_by, external_id)
+			VALUES
+			(?, ?, ?, ?, ?, ?, ?, ?,
+				(SELECT _id FROM types WHERE name=?),
+				(SELECT _id FROM licences WHERE name=?),
+				(SELECT _id FROM sources WHERE name=?),
+				?, ?, ?, ?, ?, ?, ?, ?, ?
+			)
+		`, append(append([]interface{}{attribuition.Name, attribuition.FileName, attribuition.Author, attribuition.Link,
+			attribuition.AttributionOverride, attribuition.Modified, attribuition.ModificationNotes,
+			attribuition.UpstreamLink, attribuition.Type, attribuition.Licence, attribuition.Source},
+			purchaseValues(attribuition.Purchase)...), attribuition.ValidUntil, attribuition.RenewBy, attribuition.ExternalId)...)
+		if err != nil {
+			return errors.Wrap(err, "cant exec to add attribuition")
 		}
-	}()
-	_, err = stmt.Exec(name, fileame, author, link, ctype, licence)
+		if id, err = result.LastInsertId(); err != nil {
+			return errors.Wrap(err, "cant read attribuition id")
+		}
+		if err := setCreditFields(tx, id, attribuition.Fields); err != nil {
+			return err
+		}
+		return linkCreditAuthors(tx, id, attribuition)
+	})
 	if err != nil {
-		return errors.Wrap(err, "cant exec to add attribuition")
+		return 0, err
 	}
-	return nil
+	return id, nil
+}
+
+func (s *Storage) FindAttribuitions(query domain.Query) ([]domain.Attribuition, error) {
+	whereClause, args := mountQueryWhere(query)
+	return s.findAttribuitions(whereClause, args, query.Order)
+}
+
+// GetAttribuition returns an attribuition by id, or nil when missing.
+func (s *Storage) GetAttribuition(id int64) (*domain.Attribuition, error) {
+	list, err := s.findAttribuitions(`WHERE c._id = ?`, []interface{}{id}, "ASC")
+	if err != nil || len(list) == 0 {
+		return nil, err
+	}
+	return &list[0], nil
+}
+
+// FindAttribuitionByKey returns the attribuition with the same natural key,
+// one of domain.AttribuitionKeys, or nil when missing.
+func (s *Storage) FindAttribuitionByKey(key string, attribuition domain.Attribuition) (*domain.Attribuition, error) {
+	var where string
+	var args []interface{}
+	switch key {
+	case domain.KeyFileName:
+		where, args = `WHERE c.filename = ?`, []interface{}{attribuition.FileName}
+	case domain.KeyExternalId:
+		where, args = `WHERE c.external_id = ?`, []interface{}{attribuition.ExternalId}
+	case domain.KeyLink:
+		where, args = `WHERE c.link = ? AND c.name = ? COLLATE NOCASE`, []interface{}{attribuition.Link, attribuition.Name}
+	default:
+		return nil, errors.New("unknown key " + key)
+	}
+	list, err := s.findAttribuitions(where, args, "ASC")
+	if err != nil || len(list) == 0 {
+		return nil, err
+	}
+	if len(list) > 1 {
+		return nil, errors.New("more than one attribuition with the same " + key)
+	}
+	return &list[0], nil
 }
 
-func (s *Storage) FindAttribuitions(ascDesc string, search string) ([]domain.Attribuition, error) {
+func (s *Storage) findAttribuitions(whereClause string, args []interface{}, ascDesc string) ([]domain.Attribuition, error) {
 	s.locker.Lock()
 	defer s.locker.Unlock()
 
 	list := make([]domain.Attribuition, 0)
-	whereClause, args := mountQueryWhere(search)
 	query := fmt.Sprintf(`
 		SELECT c._id, c.name, filename, author, c.link,
 			t.name as type,
 			l.name as licence,
-			l.link as licence_link
+			l.link as licence_link,
+			COALESCE(l.spdx_id, '') as licence_spdx,
+			COALESCE(l.requires_attribution, 1), COALESCE(l.allows_commercial, 1),
+			COALESCE(l.share_alike, 0), COALESCE(l.allows_derivatives, 1),
+			COALESCE(l.requires_licence_text, 0), COALESCE(l.copyleft_scope, 'none'),
+			COALESCE(l.attribution_template, ''), COALESCE(l.requires_permission, 0),
+			c.attribution_override, c.modified, c.modification_notes, c.upstream_link,
+			COALESCE(s.name, '') as source,
+			c.purchase_price, c.purchase_currency, c.purchase_date,
+			c.purchase_order, c.purchase_seats, c.purchase_account,
+			c.valid_until, c.renew_by, c.external_id,
+			c.valid_until != '' AND c.valid_until < date('now', 'localtime') as expired
 		FROM credits c
 		LEFT JOIN types t ON t._id = c.type_id
 		LEFT JOIN licences l ON l._id = c.licence_id
+		LEFT JOIN sources s ON s._id = c.source_id
 		%s
 		ORDER BY c.name COLLATE NOCASE %s
 	`, whereClause, ascDesc)
 
-	rows, err := s.db.Query(query, args...)
+	rows, err := s.ex.Query(query, args...)
 	if err != nil {
 		return nil, errors.Wrap(err, "cant read rows from attribuitions")
 	}
@@ -344,68 +747,218 @@ func (s *Storage) FindAttribuitions(ascDesc string, search string) ([]domain.Att
 	}()
 	for rows.Next() {
 		data := domain.Attribuition{}
-		if err := rows.Scan(&data.Id, &data.Name, &data.FileName, &data.Author, &data.Link, &data.Type, &data.Licence, &data.LicenceUrl); err != nil {
+		var purchase nullPurchase
+		if err := rows.Scan(&data.Id, &data.Name, &data.FileName, &data.Author, &data.Link, &data.Type, &data.Licence, &data.LicenceUrl, &data.LicenceSpdx,
+			&data.LicenceTerms.RequiresAttribution, &data.LicenceTerms.AllowsCommercial,
+			&data.LicenceTerms.ShareAlike, &data.LicenceTerms.AllowsDerivatives,
+			&data.LicenceTerms.RequiresLicenceText, &data.LicenceTerms.CopyleftScope,
+			&data.LicenceTemplate, &data.LicenceTerms.RequiresPermission, &data.AttributionOverride,
+			&data.Modified, &data.ModificationNotes, &data.UpstreamLink, &data.Source,
+			&purchase.Price, &purchase.Currency, &purchase.Date,
+			&purchase.OrderId, &purchase.Seats, &purchase.Account,
+			&data.ValidUntil, &data.RenewBy, &data.ExternalId, &data.Expired); err != nil {
 			return nil, errors.Wrap(err, "cant read row from attribuitions")
 		}
+		data.Purchase = purchase.value()
 		list = append(list, data)
 	}
+	authors, err := creditsAuthors(s.ex)
+	if err != nil {
+		return nil, err
+	}
+	links, err := creditsLinks(s.ex)
+	if err != nil {
+		return nil, err
+	}
+	permissions, err := creditsPermissions(s.ex)
+	if err != nil {
+		return nil, err
+	}
+	tags, err := creditsTags(s.ex)
+	if err != nil {
+		return nil, err
+	}
+	fields, err := creditsFields(s.ex)
+	if err != nil {
+		return nil, err
+	}
+	for i := range list {
+		list[i].Authors = authors[list[i].Id]
+		if list[i].Authors == nil {
+			list[i].Authors = make([]domain.Author, 0)
+		}
+		list[i].Links = links[list[i].Id]
+		if list[i].Links == nil {
+			list[i].Links = make([]domain.Link, 0)
+		}
+		list[i].Permissions = permissions[list[i].Id]
+		if list[i].Permissions == nil {
+			list[i].Permissions = make([]domain.Permission, 0)
+		}
+		list[i].Tags = tags[list[i].Id]
+		if list[i].Tags == nil {
+			list[i].Tags = make([]string, 0)
+		}
+		list[i].Fields = fields[list[i].Id]
+		if list[i].Fields == nil {
+			list[i].Fields = make(map[string]interface{})
+		}
+	}
 	return list, nil
 }
 
-func mountQueryWhere(q string) (string, []interface{}) {
-	if q == "" {
+func mountQueryWhere(query domain.Query) (string, []interface{}) {
+	conditions := make([]string, 0)
+	args := make([]interface{}, 0)
+	if query.Text != "" {
+		tokens := strings.Fields(query.Text)
+		joined := "%" + strings.Join(tokens, "%") + "%"
+		conditions = append(conditions, `(c.name LIKE ? OR c.author LIKE ? OR
+			c._id IN (SELECT credit_id FROM credit_fields WHERE value LIKE ?))`)
+		args = append(args, joined, joined, joined)
+	}
+	if query.Source != "" {
+		conditions = append(conditions, "s.name = ? COLLATE NOCASE")
+		args = append(args, query.Source)
+	}
+	if query.Type != "" {
+		conditions = append(conditions, `c.type_id IN (
+			WITH RECURSIVE descendants(id) AS (
+				SELECT _id FROM types WHERE name = ? COLLATE NOCASE
+				UNION SELECT child._id FROM types child JOIN descendants d ON child.parent_id = d.id
+			) SELECT id FROM descendants)`)
+		args = append(args, query.Type)
+	}
+	for _, tag := range query.Tags {
+		conditions = append(conditions, "c._id IN ("+taggedCredits+" = ?)")
+		args = append(args, tag)
+	}
+	for name, value := range query.Fields {
+		conditions = append(conditions, `c._id IN (
+			SELECT cf.credit_id FROM credit_fields cf
+			JOIN field_definitions f ON f._id = cf.field_id
+			WHERE f.name = ? AND cf.value = ? COLLATE NOCASE)`)
+		args = append(args, name, FieldText(value))
+	}
+	if len(query.ExcludeTags) > 0 {
+		marks := strings.TrimSuffix(strings.Repeat("?, ", len(query.ExcludeTags)), ", ")
+		conditions = append(conditions, "c._id NOT IN ("+taggedCredits+" IN ("+marks+"))")
+		for _, tag := range query.ExcludeTags {
+			args = append(args, tag)
+		}
+	}
+	if len(conditions) == 0 {
 		return "", nil
 	}
-	tokens := strings.Fields(q)
-	joined := "%" + strings.Join(tokens, "%") + "%"
-	return "WHERE c.name LIKE ? OR c.author LIKE ?", []interface{}{joined, joined}
+	return "WHERE " + strings.Join(conditions, " AND "), args
 }
 
-func (s *Storage) UpdateAttribuition(id int64, name string, fileame string, author string, link string, ctype string, licence string) error {
+func (s *Storage) UpdateAttribuition(attribuition domain.Attribuition) error {
 	s.locker.Lock()
 	defer s.locker.Unlock()
 
-	stmt, err := s.db.Prepare(`
-		UPDATE credits SET
-			name=?,
-			filename=?,
-			author=?,
-			link=?,
-			type_id=(SELECT _id FROM types WHERE name=?),
-			licence_id=(SELECT _id FROM licences WHERE name=?)
-		WHERE _id = ?
-	`)
-	if err != nil {
-		return errors.Wrap(err, "cant prepare to add attribuition")
-	}
-	defer func() {
-		if err := stmt.Close(); err != nil {
-			panic(errors.Wrap(err, "cant close prepare to add attribuition").Error())
+	return s.inTransaction(func(tx *sql.Tx) error {
+		_, err := tx.Exec(`
+			UPDATE credits SET
+				name=?,
+				filename=?,
+				author=?,
+				link=?,
+				attribution_override=?,
+				modified=?,
+				modification_notes=?,
+				upstream_link=?,
+				type_id=(SELECT _id FROM types WHERE name=?),
+				licence_id=(SELECT _id FROM licences WHERE name=?),
+				source_id=(SELECT _id FROM sources WHERE name=?),
+				purchase_price=?,
+				purchase_currency=?,
+				purchase_date=?,
+				purchase_order=?,
+				purchase_seats=?,
+				purchase_account=?,
+				valid_until=?,
+				renew_by=?,
+				external_id=?
+			WHERE _id = ?
+		`, append(append([]interface{}{attribuition.Name, attribuition.FileName, attribuition.Author, attribuition.Link,
+			attribuition.AttributionOverride, attribuition.Modified, attribuition.ModificationNotes,
+			attribuition.UpstreamLink, attribuition.Type, attribuition.Licence, attribuition.Source},
+			purchaseValues(attribuition.Purchase)...),
+			attribuition.ValidUntil, attribuition.RenewBy, attribuition.ExternalId, attribuition.Id)...)
+		if err != nil {
+			return errors.Wrap(err, "cant exec to update attribuition")
 		}
-	}()
-	_, err = stmt.Exec(name, fileame, author, link, ctype, licence, id)
+		if err := setCreditFields(tx, attribuition.Id, attribuition.Fields); err != nil {
+			return err
+		}
+		return linkCreditAuthors(tx, attribuition.Id, attribuition)
+	})
+}
+
+func (s *Storage) DeleteAttribuition(id int64) error {
+	s.locker.Lock()
+	defer s.locker.Unlock()
+
+	return s.inTransaction(func(tx *sql.Tx) error {
+		if _, err := tx.Exec(`DELETE FROM credit_authors WHERE credit_id = ?`, id); err != nil {
+			return errors.Wrap(err, "cant exec to unlink authors")
+		}
+		if _, err := tx.Exec(`DELETE FROM credit_links WHERE credit_id = ?`, id); err != nil {
+			return errors.Wrap(err, "cant exec to remove links")
+		}
+		if _, err := tx.Exec(`DELETE FROM permissions WHERE credit_id = ?`, id); err != nil {
+			return errors.Wrap(err, "cant exec to remove permissions")
+		}
+		if _, err := tx.Exec(`DELETE FROM attachments WHERE credit_id = ?`, id); err != nil {
+			return errors.Wrap(err, "cant exec to remove attachments")
+		}
+		if _, err := tx.Exec(`DELETE FROM credit_tags WHERE credit_id = ?`, id); err != nil {
+			return errors.Wrap(err, "cant exec to untag attribuition")
+		}
+		if _, err := tx.Exec(`DELETE FROM credit_fields WHERE credit_id = ?`, id); err != nil {
+			return errors.Wrap(err, "cant exec to remove fields")
+		}
+		if _, err := tx.Exec(`DELETE FROM credits WHERE _id = ?`, id); err != nil {
+			return errors.Wrap(err, "cant exec to delete attribuition")
+		}
+		return nil
+	})
+}
+
+func (s *Storage) GetProfile() (domain.ProjectProfile, error) {
+	s.locker.Lock()
+	defer s.locker.Unlock()
+
+	profile := domain.ProjectProfile{Platforms: make([]string, 0)}
+	var platforms string
+	err := s.ex.QueryRow(`SELECT commercial, licence, platforms FROM project WHERE _id = 1`).
+		Scan(&profile.Commercial, &profile.Licence, &platforms)
 	if err != nil {
-		return errors.Wrap(err, "cant exec to add attribuition")
+		return profile, errors.Wrap(err, "cant read project profile")
 	}
-	return nil
+	if platforms != "" {
+		profile.Platforms = strings.Split(platforms, ",")
+	}
+	return profile, nil
 }
 
-func (s *Storage) DeleteAttribuition(id int64) error {
+func (s *Storage) UpdateProfile(profile domain.ProjectProfile) error {
 	s.locker.Lock()
 	defer s.locker.Unlock()
 
-	stmt, err := s.db.Prepare(`DELETE FROM credits WHERE _id = ?`)
+	stmt, err := s.ex.Prepare(`UPDATE project SET commercial=?, licence=?, platforms=? WHERE _id = 1`)
 	if err != nil {
-		return errors.Wrap(err, "cant prepare to delete attribuition")
+		return errors.Wrap(err, "cant prepare to update project profile")
 	}
 	defer func() {
 		if err := stmt.Close(); err != nil {
-			panic(errors.Wrap(err, "cant close prepare to delete attribuition").Error())
+			panic(errors.Wrap(err, "cant close prepare to update project profile").Error())
 		}
 	}()
-	_, err = stmt.Exec(id)
+	_, err = stmt.Exec(profile.Commercial, profile.Licence, strings.Join(profile.Platforms, ","))
 	if err != nil {
-		return errors.Wrap(err, "cant exec to delete attribuition")
+		return errors.Wrap(err, "cant exec to update project profile")
 	}
 	return nil
 }
@@ -0,0 +1,96 @@
+package infra
+
+import (
+	"strings"
+
+	"github.com/marcosbitetti/godot-manage-attribuitions-plugin/intrenal/domain"
+)
+
+// termsBySpdx describes the obligations of the catalog licences seen in games.
+// Creative Commons licences are derived from their identifier.
+var termsBySpdx = map[string]domain.LicenceTerms{
+	"CC0-1.0":           publicDomainTerms(),
+	"Unlicense":         publicDomainTerms(),
+	"0BSD":              publicDomainTerms(),
+	"MIT-0":             publicDomainTerms(),
+	"WTFPL":             publicDomainTerms(),
+	"Zlib":              {AllowsCommercial: true, AllowsDerivatives: true, CopyleftScope: domain.CopyleftNone},
+	"BSL-1.0":           {AllowsCommercial: true, AllowsDerivatives: true, CopyleftScope: domain.CopyleftNone},
+	"MIT":               noticeTerms(),
+	"ISC":               noticeTerms(),
+	"BSD-2-Clause":      noticeTerms(),
+	"BSD-3-Clause":      noticeTerms(),
+	"Apache-2.0":        noticeTerms(),
+	"Beerware":          noticeTerms(),
+	"FTL":               noticeTerms(),
+	"MPL-2.0":           copyleftTerms(domain.CopyleftFile),
+	"EPL-2.0":           copyleftTerms(domain.CopyleftFile),
+	"LGPL-2.1-only":     copyleftTerms(domain.CopyleftLibrary),
+	"LGPL-2.1-or-later": copyleftTerms(domain.CopyleftLibrary),
+	"LGPL-3.0-only":     copyleftTerms(domain.CopyleftLibrary),
+	"LGPL-3.0-or-later": copyleftTerms(domain.CopyleftLibrary),
+	"OFL-1.1":           copyleftTerms(domain.CopyleftDerivative),
+	"OFL-1.1-RFN":       copyleftTerms(domain.CopyleftDerivative),
+	"OFL-1.1-no-RFN":    copyleftTerms(domain.CopyleftDerivative),
+	"GPL-2.0-only":      copyleftTerms(domain.CopyleftProject),
+	"GPL-2.0-or-later":  copyleftTerms(domain.CopyleftProject),
+	"GPL-3.0-only":      copyleftTerms(domain.CopyleftProject),
+	"GPL-3.0-or-later":  copyleftTerms(domain.CopyleftProject),
+	"AGPL-3.0-only":     copyleftTerms(domain.CopyleftProject),
+	"AGPL-3.0-or-later": copyleftTerms(domain.CopyleftProject),
+	"EUPL-1.2":          copyleftTerms(domain.CopyleftProject),
+	"GFDL-1.3-only":     copyleftTerms(domain.CopyleftProject),
+	"GFDL-1.3-or-later": copyleftTerms(domain.CopyleftProject),
+}
+
+// termsByName describes the seeded licences that have no SPDX identifier.
+var termsByName = map[string]domain.LicenceTerms{
+	"Royalty Free":               {AllowsCommercial: true, AllowsDerivatives: true, CopyleftScope: domain.CopyleftNone},
+	"OGA-BY 3.0 (Open Game Art)": domain.NewLicenceTerms(),
+	"Free Standard (Sketchfab)":  {AllowsCommercial: true, AllowsDerivatives: true, CopyleftScope: domain.CopyleftNone},
+	allRightsReserved:            {RequiresAttribution: true, RequiresPermission: true, CopyleftScope: domain.CopyleftNone},
+}
+
+// allRightsReserved is the licence of the assets used with the written
+// permission of their copyright holder.
+const allRightsReserved = "All Rights Reserved"
+
+func publicDomainTerms() domain.LicenceTerms {
+	return domain.LicenceTerms{AllowsCommercial: true, AllowsDerivatives: true, CopyleftScope: domain.CopyleftNone}
+}
+
+func noticeTerms() domain.LicenceTerms {
+	terms := domain.NewLicenceTerms()
+	terms.RequiresLicenceText = true
+	return terms
+}
+
+func copyleftTerms(scope string) domain.LicenceTerms {
+	terms := noticeTerms()
+	terms.ShareAlike = true
+	terms.CopyleftScope = scope
+	return terms
+}
+
+// LicenceTermsFor returns the known terms of a licence by SPDX identifier or,
+// for the seeded licences without one, by name. Unknown licences get the
+// default terms.
+func LicenceTermsFor(spdxId string, name string) domain.LicenceTerms {
+	if terms, ok := termsBySpdx[spdxId]; ok {
+		return terms
+	}
+	if strings.HasPrefix(spdxId, "CC-BY") {
+		terms := domain.NewLicenceTerms()
+		terms.AllowsCommercial = !strings.Contains(spdxId, "-NC")
+		terms.AllowsDerivatives = !strings.Contains(spdxId, "-ND")
+		if strings.Contains(spdxId, "-SA") {
+			terms.ShareAlike = true
+			terms.CopyleftScope = domain.CopyleftDerivative
+		}
+		return terms
+	}
+	if terms, ok := termsByName[name]; ok && spdxId == "" {
+		return terms
+	}
+	return domain.NewLicenceTerms()
+}
@@ -0,0 +1,78 @@
+package infra
+
+import (
+	_ "embed"
+	"encoding/json"
+	"strings"
+	"sync"
+
+	"github.com/marcosbitetti/godot-manage-attribuitions-plugin/intrenal/domain"
+)
+
+// spdxLicencesJson is an offline copy of the SPDX licence list. Licences common
+// in games carry their full name, canonical link and a bundled text file from
+// licences/; the others link to their page at spdx.org.
+//
+//go:embed spdx-licences.json
+var spdxLicencesJson []byte
+
+type spdxEntry struct {
+	Id         string `json:"id"`
+	Name       string `json:"name"`
+	Link       string `json:"link"`
+	Text       string `json:"text"`
+	Deprecated bool   `json:"deprecated"`
+}
+
+var (
+	spdxCatalog     []spdxEntry
+	spdxCatalogOnce sync.Once
+)
+
+func loadSpdxCatalog() []spdxEntry {
+	spdxCatalogOnce.Do(func() {
+		if err := json.Unmarshal(spdxLicencesJson, &spdxCatalog); err != nil {
+			panic("invalid embedded SPDX catalog: " + err.Error())
+		}
+	})
+	return spdxCatalog
+}
+
+// FindSpdxLicence returns the catalog licence of a SPDX identifier, with its
+// terms and text when bundled, or nil for unknown identifiers.
+func FindSpdxLicence(id string) *domain.Licence {
+	for _, entry := range loadSpdxCatalog() {
+		if strings.EqualFold(entry.Id, id) {
+			return &domain.Licence{
+				SpdxId:       entry.Id,
+				Name:         entry.Name,
+				Link:         entry.Link,
+				Text:         bundledLicenceText(entry.Text),
+				LicenceTerms: LicenceTermsFor(entry.Id, entry.Name),
+			}
+		}
+	}
+	return nil
+}
+
+// NormalizeSpdxId returns the identifier with the casing of the catalog, the
+// SPDX ids are matched ignoring case. Unknown identifiers are kept as they are.
+func NormalizeSpdxId(id string) string {
+	for _, entry := range loadSpdxCatalog() {
+		if strings.EqualFold(entry.Id, id) {
+			return entry.Id
+		}
+	}
+	return id
+}
+
+// FindSpdxByName returns the identifier of the non deprecated catalog licence
+// with the given full name, or an empty string.
+func FindSpdxByName(name string) string {
+	for _, entry := range loadSpdxCatalog() {
+		if !entry.Deprecated && entry.Name != entry.Id && strings.EqualFold(entry.Name, name) {
+			return entry.Id
+		}
+	}
+	return ""
+}
@@ -0,0 +1,23 @@
+package command
+
+import (
+	"github.com/marcosbitetti/godot-manage-attribuitions-plugin/intrenal/usecases"
+)
+
+// exitCommands are the commands that end with exit code 1 when they fail,
+// for CI pipelines. The others always exit with 0, the plugin reads the
+// status of the response.
+var exitCommands = map[string]bool{
+	"checkCompliance": true,
+}
+
+// ExitCode returns the exit code of the command that answered the response.
+func ExitCode(args []string, response []byte) int {
+	if len(args) < 3 || !exitCommands[args[2]] {
+		return 0
+	}
+	if usecases.Failed(response) {
+		return 1
+	}
+	return 0
+}
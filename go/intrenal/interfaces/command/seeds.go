@@ -0,0 +1,42 @@
+package command
+
+import (
+	"os"
+
+	"github.com/pkg/errors"
+
+	"github.com/marcosbitetti/godot-manage-attribuitions-plugin/intrenal/domain"
+	"github.com/marcosbitetti/godot-manage-attribuitions-plugin/intrenal/usecases"
+)
+
+const (
+	initCommand = "init"
+	seedFlag    = "--seed"
+)
+
+// ParseSeeds reads the seed packs given to init with --seed, a new database
+// starts with them instead of the bundled first packs. The packs of an
+// existing database are applied with the seed command.
+func ParseSeeds(args []string) ([]domain.SeedPack, error) {
+	if len(args) < 3 || args[2] != initCommand {
+		return nil, nil
+	}
+	seeds := make([]domain.SeedPack, 0)
+	for i := 3; i < len(args); i += 2 {
+		if args[i] != seedFlag || i+1 == len(args) {
+			return nil, usecases.NewErrInvalidValue()
+		}
+		pack, err := usecases.LoadSeedPack(args[i+1])
+		if err != nil {
+			return nil, err
+		}
+		seeds = append(seeds, *pack)
+	}
+	if len(seeds) == 0 {
+		return nil, nil
+	}
+	if _, err := os.Stat(args[1]); err == nil {
+		return nil, errors.New("database already exists, apply the seed packs with seed")
+	}
+	return seeds, nil
+}
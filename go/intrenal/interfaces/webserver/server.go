@@ -3,7 +3,10 @@ package webserver
 import (
 	"context"
 	"io"
+	"mime"
 	"net/http"
+	"strconv"
+	"strings"
 	"time"
 
 	"github.com/marcosbitetti/godot-manage-attribuitions-plugin/intrenal/infra"
@@ -34,6 +37,7 @@ func NewHttpServer(storage *infra.Storage, errorChan chan error) *Server {
 	}
 
 	mux.HandleFunc("/", server.handler)
+	mux.HandleFunc("GET /attachments/{id}", server.attachmentHandler)
 
 	go func(errorChan chan error) {
 		if err := server.server.ListenAndServe(); err != nil && err != http.ErrServerClosed {
@@ -89,6 +93,53 @@ func (s *Server) handler(w http.ResponseWriter, r *http.Request) {
 	w.Write(response)
 }
 
+// attachmentHandler streams the content of an attachment as a download.
+func (s *Server) attachmentHandler(w http.ResponseWriter, r *http.Request) {
+	println(r.Method, r.URL.Path)
+
+	w.Header().Set("Access-Control-Allow-Origin", "*")
+	id, err := strconv.ParseInt(r.PathValue("id"), 10, 64)
+	if err != nil {
+		http.Error(w, "invalid attachment id", http.StatusBadRequest)
+		return
+	}
+	attachment, err := s.storage.GetAttachment(id)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	if attachment == nil {
+		http.Error(w, "Not Found", http.StatusNotFound)
+		return
+	}
+
+	contentType, disposition := attachmentHeaders(attachment.MimeType)
+	w.Header().Set("Content-Type", contentType)
+	w.Header().Set("X-Content-Type-Options", "nosniff")
+	w.Header().Set("Content-Length", strconv.FormatInt(attachment.Size, 10))
+	w.Header().Set("Content-Disposition", mime.FormatMediaType(disposition, map[string]string{"filename": attachment.Name}))
+	w.Header().Set("ETag", `"`+attachment.Sha256+`"`)
+	w.WriteHeader(http.StatusOK)
+	if err := s.storage.CopyAttachment(attachment.Id, w); err != nil {
+		println("Could not stream attachment:", err.Error())
+	}
+}
+
+// attachmentHeaders picks the content type and disposition of an attachment.
+// The stored mime type comes from the user, so only plain text and images
+// are shown inline; anything else, scriptable svg and html included, is sent
+// as an opaque download.
+func attachmentHeaders(mimeType string) (string, string) {
+	mediaType, _, err := mime.ParseMediaType(mimeType)
+	if err != nil {
+		return "application/octet-stream", "attachment"
+	}
+	if mediaType == "text/plain" || (strings.HasPrefix(mediaType, "image/") && mediaType != "image/svg+xml") {
+		return mimeType, "inline"
+	}
+	return "application/octet-stream", "attachment"
+}
+
 func enqueueCommand(req *http.Request) ([]string, error) {
 	command := req.RequestURI[1:]
 	body, err := io.ReadAll(req.Body)
@@ -0,0 +1,70 @@
+package usecases
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"mime"
+	"net/http"
+	"os"
+	"path/filepath"
+
+	"github.com/pkg/errors"
+
+	"github.com/marcosbitetti/godot-manage-attribuitions-plugin/intrenal/domain"
+	"github.com/marcosbitetti/godot-manage-attribuitions-plugin/intrenal/infra"
+)
+
+type attachmentRequest struct {
+	domain.Attachment
+	Path   string `json:"path"`
+	Output string `json:"output"`
+}
+
+// AddAttachment stores the file at "path" with the attribuition with the
+// "credit" id. The name defaults to the file name and the MIME type is
+// detected from the extension or the content when omitted.
+func AddAttachment(storage *infra.Storage, args []string) []byte {
+	if len(args) < 4 {
+		return FormatJSON(nil, NewErrMissingArgument())
+	}
+	var t attachmentRequest
+	if err := json.Unmarshal([]byte(args[3]), &t); err != nil {
+		return FormatJSON(nil, errors.Wrap(err, "invalid attachment"))
+	}
+	if t.Credit == 0 || t.Path == "" {
+		return FormatJSON(nil, NewErrInvalidValue())
+	}
+	attribuition, err := storage.GetAttribuition(t.Credit)
+	if err != nil {
+		return FormatJSON(nil, errors.Wrap(err, "error adding attachment"))
+	}
+	if attribuition == nil {
+		return FormatJSON(nil, NewErrNotFound())
+	}
+	data, err := os.ReadFile(t.Path)
+	if err != nil {
+		return FormatJSON(nil, errors.Wrap(err, "cant read attachment file"))
+	}
+	if len(data) == 0 {
+		return FormatJSON(nil, NewErrInvalidValue())
+	}
+	if t.Name == "" {
+		t.Name = filepath.Base(t.Path)
+	}
+	if t.MimeType == "" {
+		t.MimeType = mime.TypeByExtension(filepath.Ext(t.Name))
+	}
+	if t.MimeType == "" {
+		t.MimeType = http.DetectContentType(data)
+	}
+	digest := sha256.Sum256(data)
+	t.Size = int64(len(data))
+	t.Sha256 = hex.EncodeToString(digest[:])
+	id, err := storage.AddAttachment(t.Attachment, data)
+	if err != nil {
+		return FormatJSON(nil, errors.Wrap(err, "error adding attachment"))
+	}
+	return FormatJSON(Created{Id: id}, nil)
+
+}
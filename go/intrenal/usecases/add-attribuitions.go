@@ -9,6 +9,8 @@ import (
 	"github.com/marcosbitetti/godot-manage-attribuitions-plugin/intrenal/infra"
 )
 
+// AddAttribuition adds an attribuition following the rules of its type. Without
+// licence it gets the default licence of its source or else of its type.
 func AddAttribuition(storage *infra.Storage, args []string) []byte {
 	if len(args) < 4 {
 		return FormatJSON(nil, NewErrMissingArgument())
@@ -17,16 +19,16 @@ func AddAttribuition(storage *infra.Storage, args []string) []byte {
 	if err := json.Unmarshal([]byte(args[3]), &t); err != nil {
 		return FormatJSON(nil, errors.Wrap(err, "invalid type"))
 	}
-	if t.Name == "" ||
-		t.Link == "" ||
-		t.Author == "" ||
-		t.Type == "" ||
-		t.Licence == "" {
-		return FormatJSON(nil, NewErrInvalidValue())
+	if err := resolveSource(storage, &t); err != nil {
+		return FormatJSON(nil, err)
 	}
-	if err := storage.AddAttribuition(t.Name, t.FileName, t.Link, t.Author, t.Type, t.Licence); err != nil {
+	if err := validateAttribuition(storage, &t); err != nil {
+		return FormatJSON(nil, err)
+	}
+	id, err := storage.AddAttribuition(t)
+	if err != nil {
 		return FormatJSON(nil, errors.Wrap(err, "error adding attribuition"))
 	}
-	return FormatJSON(SuccessMsg, nil)
+	return FormatJSON(Created{Id: id}, nil)
 
 }
@@ -0,0 +1,37 @@
+package usecases
+
+import (
+	"encoding/json"
+	"strings"
+
+	"github.com/pkg/errors"
+
+	"github.com/marcosbitetti/godot-manage-attribuitions-plugin/intrenal/domain"
+	"github.com/marcosbitetti/godot-manage-attribuitions-plugin/intrenal/infra"
+)
+
+func AddAuthor(storage *infra.Storage, args []string) []byte {
+	if len(args) < 4 {
+		return FormatJSON(nil, NewErrMissingArgument())
+	}
+	t := domain.NewAuthor("")
+	if err := json.Unmarshal([]byte(args[3]), t); err != nil {
+		return FormatJSON(nil, errors.Wrap(err, "invalid author"))
+	}
+	if strings.TrimSpace(t.Name) == "" {
+		return FormatJSON(nil, NewErrInvalidValue())
+	}
+	existing, err := storage.FindAuthor(t.Name)
+	if err != nil {
+		return FormatJSON(nil, errors.Wrap(err, "error adding author"))
+	}
+	if existing != nil {
+		return FormatJSON(nil, errors.New("author already exists"))
+	}
+	id, err := storage.AddAuthor(*t)
+	if err != nil {
+		return FormatJSON(nil, errors.Wrap(err, "error adding author"))
+	}
+	return FormatJSON(Created{Id: id}, nil)
+
+}
@@ -0,0 +1,30 @@
+package usecases
+
+import (
+	"encoding/json"
+
+	"github.com/pkg/errors"
+
+	"github.com/marcosbitetti/godot-manage-attribuitions-plugin/intrenal/domain"
+	"github.com/marcosbitetti/godot-manage-attribuitions-plugin/intrenal/infra"
+)
+
+// AddField defines a custom field of the attribuitions.
+func AddField(storage *infra.Storage, args []string) []byte {
+	if len(args) < 4 {
+		return FormatJSON(nil, NewErrMissingArgument())
+	}
+	var t domain.FieldDefinition
+	if err := json.Unmarshal([]byte(args[3]), &t); err != nil {
+		return FormatJSON(nil, errors.Wrap(err, "invalid field"))
+	}
+	if !validFieldDefinition(t) {
+		return FormatJSON(nil, NewErrInvalidValue())
+	}
+	id, err := storage.AddField(t)
+	if err != nil {
+		return FormatJSON(nil, errors.Wrap(err, "error adding field"))
+	}
+	return FormatJSON(Created{Id: id}, nil)
+
+}
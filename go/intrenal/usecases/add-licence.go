@@ -17,12 +17,55 @@ func AddLicence(storage *infra.Storage, args []string) []byte {
 	if err := json.Unmarshal([]byte(args[3]), &t); err != nil {
 		return FormatJSON(nil, errors.Wrap(err, "invalid type"))
 	}
+	// the known terms are the defaults, the informed ones are read again over them
+	t.SpdxId = infra.NormalizeSpdxId(t.SpdxId)
+	t.LicenceTerms = infra.LicenceTermsFor(t.SpdxId, t.Name)
+	if err := json.Unmarshal([]byte(args[3]), &t.LicenceTerms); err != nil {
+		return FormatJSON(nil, errors.Wrap(err, "invalid type"))
+	}
+	if !validCopyleftScope(t.CopyleftScope) {
+		return FormatJSON(nil, NewErrInvalidValue())
+	}
+	if t.SpdxId != "" {
+		if !validSpdxId(t.SpdxId) {
+			return FormatJSON(nil, NewErrInvalidValue())
+		}
+		fillFromSpdxCatalog(&t)
+	}
 	if t.Name == "" || t.Link == "" {
 		return FormatJSON(nil, NewErrInvalidValue())
 	}
-	if err := storage.AddLicence(t.Name, t.Link); err != nil {
+	id, err := storage.AddLicence(t)
+	if err != nil {
 		return FormatJSON(nil, errors.Wrap(err, "error adding type"))
 	}
-	return FormatJSON(SuccessMsg, nil)
+	return FormatJSON(Created{Id: id}, nil)
+
+}
+
+// fillFromSpdxCatalog completes the fields not informed with the catalog ones.
+func fillFromSpdxCatalog(t *domain.Licence) {
+	catalog := infra.FindSpdxLicence(t.SpdxId)
+	if catalog == nil {
+		return
+	}
+	t.SpdxId = catalog.SpdxId
+	if t.Name == "" {
+		t.Name = catalog.Name
+	}
+	if t.Link == "" {
+		t.Link = catalog.Link
+	}
+	if t.Text == "" {
+		t.Text = catalog.Text
+	}
+}
 
+func validCopyleftScope(scope string) bool {
+	for _, valid := range domain.CopyleftScopes {
+		if scope == valid {
+			return true
+		}
+	}
+	return false
 }
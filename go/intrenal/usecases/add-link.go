@@ -0,0 +1,42 @@
+package usecases
+
+import (
+	"encoding/json"
+
+	"github.com/pkg/errors"
+
+	"github.com/marcosbitetti/godot-manage-attribuitions-plugin/intrenal/domain"
+	"github.com/marcosbitetti/godot-manage-attribuitions-plugin/intrenal/infra"
+)
+
+type linkRequest struct {
+	Credit int64 `json:"credit"`
+	domain.Link
+}
+
+// AddLink adds a typed link to the attribuition with the "credit" id.
+func AddLink(storage *infra.Storage, args []string) []byte {
+	if len(args) < 4 {
+		return FormatJSON(nil, NewErrMissingArgument())
+	}
+	var t linkRequest
+	if err := json.Unmarshal([]byte(args[3]), &t); err != nil {
+		return FormatJSON(nil, errors.Wrap(err, "invalid link"))
+	}
+	if t.Credit == 0 || t.Url == "" || !validLinkKind(t.Kind) {
+		return FormatJSON(nil, NewErrInvalidValue())
+	}
+	attribuition, err := storage.GetAttribuition(t.Credit)
+	if err != nil {
+		return FormatJSON(nil, errors.Wrap(err, "error adding link"))
+	}
+	if attribuition == nil {
+		return FormatJSON(nil, NewErrNotFound())
+	}
+	id, err := storage.AddLink(t.Credit, t.Link)
+	if err != nil {
+		return FormatJSON(nil, errors.Wrap(err, "error adding link"))
+	}
+	return FormatJSON(Created{Id: id}, nil)
+
+}
@@ -0,0 +1,43 @@
+package usecases
+
+import (
+	"encoding/json"
+
+	"github.com/pkg/errors"
+
+	"github.com/marcosbitetti/godot-manage-attribuitions-plugin/intrenal/domain"
+	"github.com/marcosbitetti/godot-manage-attribuitions-plugin/intrenal/infra"
+)
+
+// AddPermission records a permission granted for the attribuition with the
+// "credit" id.
+func AddPermission(storage *infra.Storage, args []string) []byte {
+	if len(args) < 4 {
+		return FormatJSON(nil, NewErrMissingArgument())
+	}
+	var t domain.Permission
+	if err := json.Unmarshal([]byte(args[3]), &t); err != nil {
+		return FormatJSON(nil, errors.Wrap(err, "invalid permission"))
+	}
+	if t.Credit == 0 || !validPermission(t) {
+		return FormatJSON(nil, NewErrInvalidValue())
+	}
+	attribuition, err := storage.GetAttribuition(t.Credit)
+	if err != nil {
+		return FormatJSON(nil, errors.Wrap(err, "error adding permission"))
+	}
+	if attribuition == nil {
+		return FormatJSON(nil, NewErrNotFound())
+	}
+	id, err := storage.AddPermission(t)
+	if err != nil {
+		return FormatJSON(nil, errors.Wrap(err, "error adding permission"))
+	}
+	return FormatJSON(Created{Id: id}, nil)
+
+}
+
+// validPermission requires who granted it and a well formatted date.
+func validPermission(permission domain.Permission) bool {
+	return permission.Grantor != "" && validDate(permission.GrantedAt)
+}
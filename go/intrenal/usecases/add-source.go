@@ -0,0 +1,47 @@
+package usecases
+
+import (
+	"encoding/json"
+
+	"github.com/pkg/errors"
+
+	"github.com/marcosbitetti/godot-manage-attribuitions-plugin/intrenal/domain"
+	"github.com/marcosbitetti/godot-manage-attribuitions-plugin/intrenal/infra"
+)
+
+func AddSource(storage *infra.Storage, args []string) []byte {
+	if len(args) < 4 {
+		return FormatJSON(nil, NewErrMissingArgument())
+	}
+	var t domain.Source
+	if err := json.Unmarshal([]byte(args[3]), &t); err != nil {
+		return FormatJSON(nil, errors.Wrap(err, "invalid source"))
+	}
+	if err := validateSource(storage, t); err != nil {
+		return FormatJSON(nil, err)
+	}
+	id, err := storage.AddSource(t)
+	if err != nil {
+		return FormatJSON(nil, errors.Wrap(err, "error adding source"))
+	}
+	return FormatJSON(Created{Id: id}, nil)
+
+}
+
+// validateSource requires a name, an URL as base and a known default licence.
+func validateSource(storage *infra.Storage, source domain.Source) error {
+	if source.Name == "" || !isUrl(source.BaseUrl) {
+		return NewErrInvalidValue()
+	}
+	if source.DefaultLicence == "" {
+		return nil
+	}
+	licence, err := storage.FindLicence(source.DefaultLicence)
+	if err != nil {
+		return errors.Wrap(err, "error reading licence")
+	}
+	if licence == nil {
+		return NewErrInvalidValue()
+	}
+	return nil
+}
@@ -9,6 +9,8 @@ import (
 	"github.com/marcosbitetti/godot-manage-attribuitions-plugin/intrenal/infra"
 )
 
+// AddType adds a type, without requires its attribuitions need a link and an
+// author.
 func AddType(storage *infra.Storage, args []string) []byte {
 	if len(args) < 4 {
 		return FormatJSON(nil, NewErrMissingArgument())
@@ -20,9 +22,19 @@ func AddType(storage *infra.Storage, args []string) []byte {
 	if t.Name == "" {
 		return FormatJSON(nil, NewErrInvalidValue())
 	}
-	if err := storage.AddType(t.Name); err != nil {
+	if t.Requires == nil {
+		t.Requires = domain.DefaultRequirements
+	}
+	if err := validateTypeRules(storage, t); err != nil {
+		return FormatJSON(nil, err)
+	}
+	if err := validateTypeParent(storage, t); err != nil {
+		return FormatJSON(nil, err)
+	}
+	id, err := storage.AddType(t)
+	if err != nil {
 		return FormatJSON(nil, errors.Wrap(err, "error adding type"))
 	}
-	return FormatJSON(SuccessMsg, nil)
+	return FormatJSON(Created{Id: id}, nil)
 
 }
@@ -0,0 +1,130 @@
+package usecases
+
+import (
+	"html"
+	"strings"
+
+	"github.com/marcosbitetti/godot-manage-attribuitions-plugin/intrenal/domain"
+	"github.com/marcosbitetti/godot-manage-attribuitions-plugin/intrenal/infra"
+)
+
+const (
+	defaultAttributionTemplate      = "“{title}” by {author} is licensed under {licence}"
+	publicDomainAttributionTemplate = "“{title}” by {author} is marked with {licence}"
+)
+
+const (
+	FormatPlain    = "plain"
+	FormatMarkdown = "markdown"
+	FormatHtml     = "html"
+	FormatBBCode   = "bbcode"
+)
+
+// markup renders the values placed in an attribution template.
+type markup struct {
+	text func(value string) string
+	link func(value string, url string) string
+}
+
+var markups = map[string]markup{
+	FormatPlain: {
+		text: func(value string) string { return value },
+		link: func(value string, _ string) string { return value },
+	},
+	FormatMarkdown: {
+		text: markdownEscape,
+		link: func(value string, url string) string {
+			return "[" + markdownEscape(value) + "](" + url + ")"
+		},
+	},
+	FormatHtml: {
+		text: html.EscapeString,
+		link: func(value string, url string) string {
+			return `<a href="` + html.EscapeString(url) + `">` + html.EscapeString(value) + "</a>"
+		},
+	},
+	FormatBBCode: {
+		text: func(value string) string { return value },
+		link: func(value string, url string) string {
+			return "[url=" + url + "]" + value + "[/url]"
+		},
+	},
+}
+
+var markdownEscaper = strings.NewReplacer(`\`, `\\`, "[", `\[`, "]", `\]`, "*", `\*`, "_", `\_`, "`", "\\`")
+
+func markdownEscape(value string) string {
+	return markdownEscaper.Replace(value)
+}
+
+// fillAttributionTexts computes the TASL credit line of every attribuition.
+func fillAttributionTexts(attribuitions []domain.Attribuition) {
+	for i := range attribuitions {
+		attribuitions[i].AttributionText = attributionText(attribuitions[i])
+	}
+}
+
+func attributionText(attribuition domain.Attribuition) domain.AttributionText {
+	template := attributionTemplate(attribuition)
+	return domain.AttributionText{
+		Plain:    renderAttribution(template, attribuition, markups[FormatPlain]),
+		Markdown: renderAttribution(template, attribuition, markups[FormatMarkdown]),
+		Html:     renderAttribution(template, attribuition, markups[FormatHtml]),
+		BBCode:   renderAttribution(template, attribuition, markups[FormatBBCode]),
+	}
+}
+
+// attributionTemplate picks the credit override, then the licence template,
+// then the default one for the licence terms.
+func attributionTemplate(attribuition domain.Attribuition) string {
+	if attribuition.AttributionOverride != "" {
+		return attribuition.AttributionOverride
+	}
+	if attribuition.LicenceTemplate != "" {
+		return attribuition.LicenceTemplate
+	}
+	if !attribuition.LicenceTerms.RequiresAttribution {
+		return publicDomainAttributionTemplate
+	}
+	return defaultAttributionTemplate
+}
+
+// renderAttribution replaces the template placeholders: {title}, {author},
+// {source}, {licence}, {licenceUrl} and {field:<name>} for the custom fields.
+// Title and licence are linked when the markup supports links. Modified
+// assets end with "modified from original", linked to the upstream file.
+func renderAttribution(template string, attribuition domain.Attribuition, m markup) string {
+	linked := func(value string, url string) string {
+		if !isUrl(url) {
+			return m.text(value)
+		}
+		return m.link(value, url)
+	}
+	author := attribuition.Author
+	if author == "" {
+		author = "Unknown"
+	}
+	licence := spdxShortName(attribuition.LicenceSpdx)
+	if licence == "" {
+		licence = attribuition.Licence
+	}
+	pairs := []string{
+		"{title}", linked(attribuition.Name, attribuition.Link),
+		"{author}", m.text(author),
+		"{source}", linked(attribuition.Link, attribuition.Link),
+		"{licence}", linked(licence, attribuition.LicenceUrl),
+		"{licenceUrl}", linked(attribuition.LicenceUrl, attribuition.LicenceUrl),
+	}
+	for name, value := range attribuition.Fields {
+		pairs = append(pairs, "{field:"+name+"}", m.text(infra.FieldText(value)))
+	}
+	text := strings.NewReplacer(pairs...).Replace(template)
+	if attribuition.Modified {
+		text += ", modified from " + linked("original", attribuition.UpstreamLink)
+	}
+	return text
+}
+
+func isUrl(value string) bool {
+	return strings.HasPrefix(value, "http://") || strings.HasPrefix(value, "https://")
+}
@@ -0,0 +1,152 @@
+package usecases
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/pkg/errors"
+
+	"github.com/marcosbitetti/godot-manage-attribuitions-plugin/intrenal/infra"
+)
+
+type batchOperation struct {
+	Command string          `json:"command"`
+	Payload json.RawMessage `json:"payload"`
+	// As names the id created by the operation, later payloads refer to it
+	// as "$name". The position in the batch works too, as "$0", "$1"...
+	As string `json:"as"`
+}
+
+// notInBatch are the commands that make no sense inside a batch.
+var notInBatch = map[string]bool{
+	"batch": true,
+	"help":  true,
+	"init":  true,
+}
+
+// the batch command runs the other commands, so it is registered apart to
+// avoid an initialization cycle.
+func init() {
+	commands["batch"] = Batch
+}
+
+// Batch runs many operations in one transaction. When one of them fails all
+// the changes are rolled back, else the answer of each operation is returned
+// in order.
+func Batch(storage *infra.Storage, args []string) []byte {
+	if len(args) < 4 {
+		return FormatJSON(nil, NewErrMissingArgument())
+	}
+	var operations []batchOperation
+	if err := json.Unmarshal([]byte(args[3]), &operations); err != nil {
+		return FormatJSON(nil, errors.Wrap(err, "invalid batch"))
+	}
+	if len(operations) == 0 {
+		return FormatJSON(nil, NewErrInvalidValue())
+	}
+	for i, operation := range operations {
+		if _, ok := commands[operation.Command]; !ok || notInBatch[operation.Command] {
+			return FormatJSON(nil, fmt.Errorf("operation %d has an invalid command: %s", i, operation.Command))
+		}
+	}
+
+	results := make([]json.RawMessage, 0, len(operations))
+	err := storage.Batch(func(batch *infra.Storage) error {
+		refs := map[string]int64{}
+		for i, operation := range operations {
+			payload, err := resolveRefs(operation.Payload, refs)
+			if err != nil {
+				return errors.Wrapf(err, "operation %d (%s) failed", i, operation.Command)
+			}
+			params := []string{"", "", operation.Command}
+			if len(payload) > 0 {
+				params = append(params, string(payload))
+			}
+			response := commands[operation.Command](batch, params)
+			if message, failed := operationError(response); failed {
+				return fmt.Errorf("operation %d (%s) failed: %s", i, operation.Command, message)
+			}
+			if id, ok := createdId(response); ok {
+				refs[strconv.Itoa(i)] = id
+				if operation.As != "" {
+					refs[operation.As] = id
+				}
+			}
+			results = append(results, json.RawMessage(response))
+		}
+		return nil
+	})
+	if err != nil {
+		return FormatJSON(nil, errors.Wrap(err, "batch rolled back"))
+	}
+	return FormatJSON(results, nil)
+
+}
+
+// operationError tells if the answer of an operation is not a success, and
+// why. An answer that can't be read is a failure too.
+func operationError(response []byte) (string, bool) {
+	var parsed struct {
+		Status  string `json:"status"`
+		Message string `json:"message"`
+	}
+	if err := json.Unmarshal(response, &parsed); err != nil {
+		return string(response), true
+	}
+	if parsed.Message == "" {
+		parsed.Message = parsed.Status
+	}
+	return parsed.Message, parsed.Status != StatusSuccess
+}
+
+// createdId reads the id answered by the add commands.
+func createdId(response []byte) (int64, bool) {
+	var parsed struct {
+		Data struct {
+			Id *int64 `json:"_id"`
+		} `json:"data"`
+	}
+	if err := json.Unmarshal(response, &parsed); err != nil || parsed.Data.Id == nil {
+		return 0, false
+	}
+	return *parsed.Data.Id, true
+}
+
+// resolveRefs replaces the strings "$name" of the payload with the ids
+// created before in the batch. Strings that are not a known reference are
+// kept as they are.
+func resolveRefs(payload json.RawMessage, refs map[string]int64) (json.RawMessage, error) {
+	if len(payload) == 0 || len(refs) == 0 {
+		return payload, nil
+	}
+	decoder := json.NewDecoder(bytes.NewReader(payload))
+	decoder.UseNumber()
+	var value interface{}
+	if err := decoder.Decode(&value); err != nil {
+		return nil, errors.Wrap(err, "invalid payload")
+	}
+	return json.Marshal(replaceRefs(value, refs))
+}
+
+func replaceRefs(value interface{}, refs map[string]int64) interface{} {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		for key, item := range v {
+			v[key] = replaceRefs(item, refs)
+		}
+	case []interface{}:
+		for i, item := range v {
+			v[i] = replaceRefs(item, refs)
+		}
+	case string:
+		if strings.HasPrefix(v, "$") {
+			if id, ok := refs[v[1:]]; ok {
+				return id
+			}
+		}
+	}
+	return value
+}
@@ -0,0 +1,124 @@
+package usecases
+
+import (
+	"bytes"
+	"encoding/json"
+
+	"github.com/pkg/errors"
+
+	"github.com/marcosbitetti/godot-manage-attribuitions-plugin/intrenal/domain"
+	"github.com/marcosbitetti/godot-manage-attribuitions-plugin/intrenal/infra"
+)
+
+type bulkUpdateRequest struct {
+	// Query selects the attribuitions like listAttribuitions, an empty
+	// object selects them all.
+	Query json.RawMessage `json:"query"`
+	// Changes are the fields to set, written like the updateAttribuition
+	// payload without the id.
+	Changes json.RawMessage `json:"changes"`
+	DryRun  bool            `json:"dryRun"`
+}
+
+type bulkUpdateReport struct {
+	Matched int  `json:"matched"`
+	Changed int  `json:"changed"`
+	DryRun  bool `json:"dryRun"`
+	// Attribuitions are the changed records as they are, or would be,
+	// after the update.
+	Attribuitions []domain.Attribuition `json:"attribuitions"`
+}
+
+// BulkUpdateAttribuitions applies the same changes to every attribuition the
+// query finds, in a single transaction. When one of them breaks the rules of
+// its type nothing is changed. A dry run only reports the changes.
+func BulkUpdateAttribuitions(storage *infra.Storage, args []string) []byte {
+	if len(args) < 4 {
+		return FormatJSON(nil, NewErrMissingArgument())
+	}
+	var request bulkUpdateRequest
+	if err := json.Unmarshal([]byte(args[3]), &request); err != nil {
+		return FormatJSON(nil, errors.Wrap(err, "invalid bulk update"))
+	}
+	if len(request.Query) == 0 || len(request.Changes) == 0 {
+		return FormatJSON(nil, NewErrInvalidValue())
+	}
+	query, err := domain.NewQuery(string(request.Query))
+	if err != nil {
+		return FormatJSON(nil, err)
+	}
+	var changes domain.Attribuition
+	if err := json.Unmarshal(request.Changes, &changes); err != nil {
+		return FormatJSON(nil, errors.Wrap(err, "invalid type"))
+	}
+	if changes.Id != 0 {
+		return FormatJSON(nil, NewErrInvalidValue())
+	}
+
+	report := bulkUpdateReport{DryRun: request.DryRun, Attribuitions: make([]domain.Attribuition, 0)}
+	err = storage.Batch(func(batch *infra.Storage) error {
+		attribuitions, err := batch.FindAttribuitions(*query)
+		if err != nil {
+			return err
+		}
+		report.Matched = len(attribuitions)
+		for i := range attribuitions {
+			current := &attribuitions[i]
+			before, err := json.Marshal(current)
+			if err != nil {
+				return err
+			}
+			if err := mergeAttribuition(batch, current, changes, string(request.Changes)); err != nil {
+				return errors.Wrapf(err, "attribuition %d", current.Id)
+			}
+			after, err := json.Marshal(current)
+			if err != nil {
+				return err
+			}
+			if bytes.Equal(before, after) {
+				continue
+			}
+			if request.DryRun {
+				if err := previewAuthors(batch, current); err != nil {
+					return err
+				}
+				report.Attribuitions = append(report.Attribuitions, *current)
+				continue
+			}
+			if err := batch.UpdateAttribuition(*current); err != nil {
+				return err
+			}
+			// the stored row has the authors linked again
+			updated, err := batch.GetAttribuition(current.Id)
+			if err != nil {
+				return err
+			}
+			report.Attribuitions = append(report.Attribuitions, *updated)
+		}
+		return nil
+	})
+	if err != nil {
+		return FormatJSON(nil, errors.Wrap(err, "error updating attribuitions"))
+	}
+	report.Changed = len(report.Attribuitions)
+	fillAttributionTexts(report.Attribuitions)
+	return FormatJSON(report, nil)
+
+}
+
+// previewAuthors fills the authors a changed author name would be linked to,
+// like the storage does on update.
+func previewAuthors(storage *infra.Storage, attribuition *domain.Attribuition) error {
+	if attribuition.Authors != nil || attribuition.Author == "" {
+		return nil
+	}
+	author, err := storage.FindAuthor(attribuition.Author)
+	if err != nil {
+		return err
+	}
+	if author == nil {
+		author = &domain.Author{Name: attribuition.Author}
+	}
+	attribuition.Authors = []domain.Author{*author}
+	return nil
+}
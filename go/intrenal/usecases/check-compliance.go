@@ -0,0 +1,120 @@
+package usecases
+
+import (
+	"github.com/pkg/errors"
+
+	"github.com/marcosbitetti/godot-manage-attribuitions-plugin/intrenal/domain"
+	"github.com/marcosbitetti/godot-manage-attribuitions-plugin/intrenal/infra"
+)
+
+const (
+	RuleNonCommercial      = "non-commercial"
+	RuleCopyleft           = "copyleft"
+	RuleMissingNotice      = "missing-notice"
+	RuleMissingAttribution = "missing-attribution"
+	RuleNoDerivatives      = "no-derivatives"
+	RuleExpired            = "expired"
+	RuleMissingPermission  = "missing-permission"
+)
+
+// CheckCompliance compares the licence terms of every attribuition with the
+// project profile. Any violation turns the response status into "failure", so
+// the command line exits with a non-zero code.
+func CheckCompliance(storage *infra.Storage, _ []string) []byte {
+	profile, err := storage.GetProfile()
+	if err != nil {
+		return FormatJSON(nil, errors.Wrap(err, "error checking compliance"))
+	}
+	attribuitions, err := storage.FindAttribuitions(domain.Query{Order: "ASC"})
+	if err != nil {
+		return FormatJSON(nil, err)
+	}
+	texts := make(map[string]string)
+	for _, attribuition := range attribuitions {
+		if _, ok := texts[attribuition.Licence]; ok {
+			continue
+		}
+		licence, err := storage.FindLicence(attribuition.Licence)
+		if err != nil {
+			return FormatJSON(nil, err)
+		}
+		texts[attribuition.Licence] = ""
+		if licence != nil {
+			texts[attribuition.Licence] = licence.Text
+		}
+	}
+
+	report := domain.NewComplianceReport(profile)
+	for _, attribuition := range attribuitions {
+		report.Checked++
+		report.Violations = append(report.Violations,
+			complianceViolations(profile, attribuition, texts[attribuition.Licence])...)
+	}
+	if len(report.Violations) > 0 {
+		return FormatFailure(report)
+	}
+	return FormatJSON(report, nil)
+}
+
+// complianceViolations returns the rules broken by an attribuition, each one
+// with a message for the producers.
+func complianceViolations(profile domain.ProjectProfile, attribuition domain.Attribuition, licenceText string) []domain.ComplianceViolation {
+	terms := attribuition.LicenceTerms
+	violations := make([]domain.ComplianceViolation, 0)
+	add := func(rule string, message string) {
+		violations = append(violations, domain.ComplianceViolation{
+			Rule:     rule,
+			Id:       attribuition.Id,
+			Name:     attribuition.Name,
+			FileName: attribuition.FileName,
+			Licence:  attribuition.Licence,
+			Message:  message,
+		})
+	}
+	if terms.RequiresPermission {
+		if len(attribuition.Permissions) == 0 {
+			add(RuleMissingPermission, "licence requires a permission of the copyright holder but none was granted")
+		}
+		terms = grantedTerms(terms, attribuition.Permissions)
+	}
+	if profile.Commercial && !terms.AllowsCommercial {
+		add(RuleNonCommercial, "licence doesn't allow commercial use")
+	}
+	if terms.CopyleftScope == domain.CopyleftProject && !copyleftProject(profile.Licence) {
+		add(RuleCopyleft, "licence requires the whole project to be released under a copyleft licence")
+	}
+	if (terms.ShareAlike || terms.RequiresLicenceText) && licenceText == "" &&
+		(terms.RequiresLicenceText || attribuition.LicenceUrl == "") {
+		add(RuleMissingNotice, "licence notice can't be generated, store the licence text")
+	}
+	if attribuition.Modified && !terms.AllowsDerivatives {
+		add(RuleNoDerivatives, "licence doesn't allow derivatives but the asset was modified")
+	}
+	if terms.RequiresAttribution && attribuition.Author == "" {
+		add(RuleMissingAttribution, "licence requires attribution but no author is set")
+	}
+	if attribuition.Expired {
+		add(RuleExpired, "licence expired on "+attribuition.ValidUntil+", renew it or remove the asset")
+	}
+	return violations
+}
+
+// grantedTerms widens the terms of a licence with the uses allowed by the
+// permissions granted.
+func grantedTerms(terms domain.LicenceTerms, permissions []domain.Permission) domain.LicenceTerms {
+	for _, permission := range permissions {
+		terms.AllowsCommercial = terms.AllowsCommercial || permission.Commercial
+		terms.AllowsDerivatives = terms.AllowsDerivatives || permission.Derivatives
+	}
+	return terms
+}
+
+// copyleftProject tells if the project licence keeps the whole project open.
+func copyleftProject(licence string) bool {
+	if licence == "" {
+		return false
+	}
+	licence = infra.NormalizeSpdxId(licence)
+	terms := infra.LicenceTermsFor(licence, licence)
+	return terms.ShareAlike && terms.CopyleftScope == domain.CopyleftProject
+}
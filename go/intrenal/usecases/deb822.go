@@ -0,0 +1,64 @@
+package usecases
+
+import (
+	"strings"
+)
+
+// deb822Paragraph holds the fields of a single Debian control paragraph,
+// keyed by lower case field name.
+type deb822Paragraph map[string]string
+
+// parseDeb822 splits a Debian control styled document (like .reuse/dep5 or
+// debian/copyright) into paragraphs. Continuation lines are joined with a
+// line break and a single "." line stands for an empty line.
+func parseDeb822(content string) []deb822Paragraph {
+	paragraphs := make([]deb822Paragraph, 0)
+	current := deb822Paragraph{}
+	lastKey := ""
+	flush := func() {
+		if len(current) > 0 {
+			paragraphs = append(paragraphs, current)
+		}
+		current = deb822Paragraph{}
+		lastKey = ""
+	}
+	for _, line := range strings.Split(strings.ReplaceAll(content, "\r\n", "\n"), "\n") {
+		if strings.TrimSpace(line) == "" {
+			flush()
+			continue
+		}
+		if strings.HasPrefix(line, "#") {
+			continue
+		}
+		if line[0] == ' ' || line[0] == '\t' {
+			if lastKey == "" {
+				continue
+			}
+			value := strings.TrimSpace(line)
+			if value == "." {
+				value = ""
+			}
+			current[lastKey] += "\n" + value
+			continue
+		}
+		pos := strings.Index(line, ":")
+		if pos < 0 {
+			continue
+		}
+		lastKey = strings.ToLower(strings.TrimSpace(line[:pos]))
+		current[lastKey] = strings.TrimSpace(line[pos+1:])
+	}
+	flush()
+	return paragraphs
+}
+
+// lines returns the non empty lines of a field.
+func (p deb822Paragraph) lines(key string) []string {
+	list := make([]string, 0)
+	for _, line := range strings.Split(p[key], "\n") {
+		if line = strings.TrimSpace(line); line != "" {
+			list = append(list, line)
+		}
+	}
+	return list
+}
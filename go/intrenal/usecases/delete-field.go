@@ -0,0 +1,29 @@
+package usecases
+
+import (
+	"encoding/json"
+
+	"github.com/pkg/errors"
+
+	"github.com/marcosbitetti/godot-manage-attribuitions-plugin/intrenal/domain"
+	"github.com/marcosbitetti/godot-manage-attribuitions-plugin/intrenal/infra"
+)
+
+// DeleteField removes a custom field and its values from every attribuition.
+func DeleteField(storage *infra.Storage, args []string) []byte {
+	if len(args) < 4 {
+		return FormatJSON(nil, NewErrMissingArgument())
+	}
+	var t domain.FieldDefinition
+	if err := json.Unmarshal([]byte(args[3]), &t); err != nil {
+		return FormatJSON(nil, errors.Wrap(err, "invalid field"))
+	}
+	if t.Id == 0 {
+		return FormatJSON(nil, NewErrInvalidValue())
+	}
+	if err := storage.DeleteField(t.Id); err != nil {
+		return FormatJSON(nil, errors.Wrap(err, "error deleting field"))
+	}
+	return FormatJSON(SuccessMsg, nil)
+
+}
@@ -22,3 +22,13 @@ func NewErrInvalidValue() error {
 	return ErrInvalidValue{}
 }
 
+// ErrNotFound represents an error indicating that the requested record doesn't exist.
+type ErrNotFound struct{}
+
+func (e ErrNotFound) Error() string {
+	return "not found"
+}
+
+func NewErrNotFound() error {
+	return ErrNotFound{}
+}
@@ -0,0 +1,79 @@
+package usecases
+
+import (
+	"encoding/json"
+	"sort"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/marcosbitetti/godot-manage-attribuitions-plugin/intrenal/domain"
+	"github.com/marcosbitetti/godot-manage-attribuitions-plugin/intrenal/infra"
+)
+
+const defaultExpiringDays = 30
+
+type expiringRequest struct {
+	Days *int `json:"days"`
+}
+
+// ExpiringLicences lists the attribuitions whose licence ends or must be
+// renewed within the informed days, 30 by default. Expired ones are listed
+// too, the nearest first.
+func ExpiringLicences(storage *infra.Storage, args []string) []byte {
+	request := expiringRequest{}
+	if len(args) > 3 {
+		if err := json.Unmarshal([]byte(args[3]), &request); err != nil {
+			return FormatJSON(nil, errors.Wrap(err, "invalid request"))
+		}
+	}
+	days := defaultExpiringDays
+	if request.Days != nil {
+		days = *request.Days
+	}
+	if days < 0 {
+		return FormatJSON(nil, NewErrInvalidValue())
+	}
+	attribuitions, err := storage.FindAttribuitions(domain.Query{Order: "ASC"})
+	if err != nil {
+		return FormatJSON(nil, err)
+	}
+	now := today()
+	list := make([]domain.ExpiringCredit, 0)
+	for _, attribuition := range attribuitions {
+		left, ok := nearestDeadline(attribuition, now)
+		if !ok || left > days {
+			continue
+		}
+		list = append(list, domain.ExpiringCredit{
+			Id:         attribuition.Id,
+			Name:       attribuition.Name,
+			FileName:   attribuition.FileName,
+			Licence:    attribuition.Licence,
+			ValidUntil: attribuition.ValidUntil,
+			RenewBy:    attribuition.RenewBy,
+			Expired:    attribuition.Expired,
+			DaysLeft:   left,
+		})
+	}
+	sort.SliceStable(list, func(i, j int) bool { return list[i].DaysLeft < list[j].DaysLeft })
+	return FormatJSON(list, nil)
+}
+
+// nearestDeadline returns the days to the nearest of the expiry and renewal
+// dates, ok is false when the attribuition has none.
+func nearestDeadline(attribuition domain.Attribuition, now time.Time) (int, bool) {
+	ok := false
+	nearest := 0
+	for _, date := range []string{attribuition.ValidUntil, attribuition.RenewBy} {
+		if date == "" {
+			continue
+		}
+		left := daysUntil(date, now)
+		if !ok || left < nearest {
+			nearest = left
+		}
+		ok = true
+	}
+	return nearest, ok
+}
@@ -0,0 +1,32 @@
+package usecases
+
+import (
+	"time"
+)
+
+// dateLayout is the YYYY-MM-DD format of the dates kept in attribuitions.
+const dateLayout = "2006-01-02"
+
+// validDate tells if an optional date is empty or well formatted.
+func validDate(value string) bool {
+	if value == "" {
+		return true
+	}
+	_, err := time.Parse(dateLayout, value)
+	return err == nil
+}
+
+// daysUntil counts the days from today to a date, negative when it passed.
+func daysUntil(value string, today time.Time) int {
+	date, err := time.Parse(dateLayout, value)
+	if err != nil {
+		return 0
+	}
+	return int(date.Sub(today).Hours() / 24)
+}
+
+// today is the current local date at midnight, as dates are parsed in UTC.
+func today() time.Time {
+	date, _ := time.Parse(dateLayout, time.Now().Format(dateLayout))
+	return date
+}
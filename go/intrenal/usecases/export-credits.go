@@ -0,0 +1,243 @@
+package usecases
+
+import (
+	"encoding/json"
+	"html"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/pkg/errors"
+
+	"github.com/marcosbitetti/godot-manage-attribuitions-plugin/intrenal/domain"
+	"github.com/marcosbitetti/godot-manage-attribuitions-plugin/intrenal/infra"
+)
+
+type creditsRequest struct {
+	tagRules
+	Output string `json:"output"`
+	Format string `json:"format"`
+	Title  string `json:"title"`
+	// GroupBy splits the page in sections, empty, "author" or "type".
+	GroupBy string `json:"groupBy"`
+	// Links are the kinds of links shown after each attribution text.
+	Links []string `json:"links"`
+}
+
+const (
+	groupByAuthor = "author"
+	groupByType   = "type"
+)
+
+// creditsSection is a titled part of a credits page, untitled when the page
+// isn't grouped. Level is how deep the section is nested, subtypes are nested
+// under their parent type.
+type creditsSection struct {
+	Title         string
+	Level         int
+	Attribuitions []domain.Attribuition
+}
+
+// ExportCredits writes the attribution text of every attribuition as a credits
+// page in plain text, markdown, html or bbcode. Without an output path the
+// document is returned as data.
+func ExportCredits(storage *infra.Storage, args []string) []byte {
+	request := creditsRequest{}
+	if len(args) > 3 {
+		if err := json.Unmarshal([]byte(args[3]), &request); err != nil {
+			return FormatJSON(nil, errors.Wrap(err, "invalid export"))
+		}
+	}
+	if request.Format == "" {
+		request.Format = FormatPlain
+	}
+	if _, ok := markups[request.Format]; !ok {
+		return FormatJSON(nil, NewErrInvalidValue())
+	}
+	if request.GroupBy != "" && request.GroupBy != groupByAuthor && request.GroupBy != groupByType {
+		return FormatJSON(nil, NewErrInvalidValue())
+	}
+	for _, kind := range request.Links {
+		if !validLinkKind(kind) {
+			return FormatJSON(nil, NewErrInvalidValue())
+		}
+	}
+	if request.Title == "" {
+		request.Title = "Credits"
+	}
+	attribuitions, err := storage.FindAttribuitions(request.query())
+	if err != nil {
+		return FormatJSON(nil, err)
+	}
+	fillAttributionTexts(attribuitions)
+	var sections []creditsSection
+	if request.GroupBy == groupByType {
+		types, err := storage.ListTypes()
+		if err != nil {
+			return FormatJSON(nil, err)
+		}
+		sections = groupCreditsByType(typeTree(types), attribuitions)
+	} else {
+		sections = groupCredits(request.GroupBy, attribuitions)
+	}
+	document := formatCredits(request, sections)
+	if request.Output == "" {
+		return FormatJSON(document, nil)
+	}
+	if err := writeExport(request.Output, document); err != nil {
+		return FormatJSON(nil, err)
+	}
+	return FormatJSON(SuccessMsg, nil)
+}
+
+// groupCredits splits the attribuitions by author, sorted by name. An
+// attribuition with many authors is listed under each one of them.
+func groupCredits(groupBy string, attribuitions []domain.Attribuition) []creditsSection {
+	if groupBy != groupByAuthor {
+		return []creditsSection{{Attribuitions: attribuitions}}
+	}
+	byAuthor := make(map[string]*creditsSection)
+	sections := make([]*creditsSection, 0)
+	add := func(name string, attribuition domain.Attribuition) {
+		key := strings.ToLower(name)
+		section, ok := byAuthor[key]
+		if !ok {
+			section = &creditsSection{Title: name}
+			byAuthor[key] = section
+			sections = append(sections, section)
+		}
+		section.Attribuitions = append(section.Attribuitions, attribuition)
+	}
+	for _, attribuition := range attribuitions {
+		if len(attribuition.Authors) == 0 {
+			add("Unknown", attribuition)
+		}
+		for _, author := range attribuition.Authors {
+			add(author.Name, attribuition)
+		}
+	}
+	sort.SliceStable(sections, func(i, j int) bool {
+		return strings.ToLower(sections[i].Title) < strings.ToLower(sections[j].Title)
+	})
+	list := make([]creditsSection, 0, len(sections))
+	for _, section := range sections {
+		list = append(list, *section)
+	}
+	return list
+}
+
+// groupCreditsByType nests the sections following the type tree. A type is
+// listed when it or one of its descendants has attribuitions, the ones of an
+// unknown type are listed last.
+func groupCreditsByType(tree []domain.Type, attribuitions []domain.Attribuition) []creditsSection {
+	byType := make(map[string][]domain.Attribuition)
+	for _, attribuition := range attribuitions {
+		key := strings.ToLower(attribuition.Type)
+		byType[key] = append(byType[key], attribuition)
+	}
+	sections := make([]creditsSection, 0)
+	var nest func(types []domain.Type, level int) int
+	nest = func(types []domain.Type, level int) int {
+		total := 0
+		for _, t := range types {
+			key := strings.ToLower(t.Name)
+			at := len(sections)
+			sections = append(sections, creditsSection{Title: t.Name, Level: level, Attribuitions: byType[key]})
+			delete(byType, key)
+			count := len(sections[at].Attribuitions) + nest(t.Children, level+1)
+			if count == 0 {
+				sections = sections[:at]
+			}
+			total += count
+		}
+		return total
+	}
+	nest(tree, 0)
+	unknown := make([]domain.Attribuition, 0)
+	for _, attribuition := range attribuitions {
+		if _, ok := byType[strings.ToLower(attribuition.Type)]; ok {
+			unknown = append(unknown, attribuition)
+		}
+	}
+	if len(unknown) > 0 {
+		sections = append(sections, creditsSection{Title: unknownGroup, Attribuitions: unknown})
+	}
+	return sections
+}
+
+// sectionHeading is the heading level of a section, from 2 under the title up
+// to the deepest one the markup has.
+func sectionHeading(section creditsSection) int {
+	if section.Level > 4 {
+		return 6
+	}
+	return section.Level + 2
+}
+
+func formatCredits(request creditsRequest, sections []creditsSection) string {
+	var builder strings.Builder
+	switch request.Format {
+	case FormatMarkdown:
+		builder.WriteString("# " + markdownEscape(request.Title) + "\n")
+		for _, section := range sections {
+			builder.WriteString("\n")
+			if section.Title != "" {
+				builder.WriteString(strings.Repeat("#", sectionHeading(section)) + " " + markdownEscape(section.Title) + "\n")
+				if len(section.Attribuitions) == 0 {
+					continue
+				}
+				builder.WriteString("\n")
+			}
+			for _, attribuition := range section.Attribuitions {
+				builder.WriteString("- " + attribuition.AttributionText.Markdown + creditLinks(request, attribuition) + "\n")
+			}
+		}
+	case FormatHtml:
+		builder.WriteString("<h1>" + html.EscapeString(request.Title) + "</h1>\n")
+		for _, section := range sections {
+			if section.Title != "" {
+				heading := strconv.Itoa(sectionHeading(section))
+				builder.WriteString("<h" + heading + ">" + html.EscapeString(section.Title) + "</h" + heading + ">\n")
+			}
+			if len(section.Attribuitions) == 0 {
+				continue
+			}
+			builder.WriteString("<ul>\n")
+			for _, attribuition := range section.Attribuitions {
+				builder.WriteString("<li>" + attribuition.AttributionText.Html + creditLinks(request, attribuition) + "</li>\n")
+			}
+			builder.WriteString("</ul>\n")
+		}
+	case FormatBBCode:
+		builder.WriteString("[b]" + request.Title + "[/b]\n")
+		for _, section := range sections {
+			if section.Title != "" {
+				builder.WriteString(strings.Repeat("  ", section.Level) + "[u]" + section.Title + "[/u]\n")
+			}
+			if len(section.Attribuitions) == 0 {
+				continue
+			}
+			builder.WriteString("[list]\n")
+			for _, attribuition := range section.Attribuitions {
+				builder.WriteString("[*]" + attribuition.AttributionText.BBCode + creditLinks(request, attribuition) + "\n")
+			}
+			builder.WriteString("[/list]\n")
+		}
+	default:
+		builder.WriteString(request.Title + "\n")
+		for _, section := range sections {
+			builder.WriteString("\n")
+			if section.Title != "" {
+				builder.WriteString(strings.Repeat("  ", section.Level) + section.Title + "\n")
+			}
+			for _, attribuition := range section.Attribuitions {
+				builder.WriteString(attribuition.AttributionText.Plain + creditLinks(request, attribuition) + "\n")
+			}
+		}
+	}
+	return builder.String()
+}
+
+func creditLinks(request creditsRequest, attribuition domain.Attribuition) string {
+	return formatLinks(attribuitionLinks(attribuition, request.Links), request.Format)
+}
@@ -0,0 +1,175 @@
+package usecases
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/pkg/errors"
+
+	"github.com/marcosbitetti/godot-manage-attribuitions-plugin/intrenal/domain"
+	"github.com/marcosbitetti/godot-manage-attribuitions-plugin/intrenal/infra"
+)
+
+const dep5Format = "https://www.debian.org/doc/packaging-manuals/copyright-format/1.0/"
+
+// dep5UnknownCopyright fills the required Copyright field of the attribuitions
+// without authors, imports don't read it as an author.
+const dep5UnknownCopyright = "Unknown"
+
+type exportRequest struct {
+	tagRules
+	Output       string `json:"output"`
+	UpstreamName string `json:"upstreamName"`
+	Source       string `json:"source"`
+}
+
+// ExportDep5 writes the attribuitions as a machine-readable debian/copyright
+// file. Without an output path the document is returned as data.
+func ExportDep5(storage *infra.Storage, args []string) []byte {
+	request := exportRequest{}
+	if len(args) > 3 {
+		if err := json.Unmarshal([]byte(args[3]), &request); err != nil {
+			return FormatJSON(nil, errors.Wrap(err, "invalid export"))
+		}
+	}
+	attribuitions, err := storage.FindAttribuitions(request.query())
+	if err != nil {
+		return FormatJSON(nil, err)
+	}
+	texts := make(map[string]string)
+	for _, attribuition := range attribuitions {
+		if _, ok := texts[attribuition.Licence]; ok {
+			continue
+		}
+		licence, err := storage.FindLicence(attribuition.Licence)
+		if err != nil {
+			return FormatJSON(nil, err)
+		}
+		texts[attribuition.Licence] = ""
+		if licence != nil {
+			texts[attribuition.Licence] = licence.Text
+		}
+	}
+	document := formatDep5(attribuitions, texts, request)
+	if request.Output == "" {
+		return FormatJSON(document, nil)
+	}
+	if err := writeExport(request.Output, document); err != nil {
+		return FormatJSON(nil, err)
+	}
+	return FormatJSON(SuccessMsg, nil)
+}
+
+func writeExport(output string, content string) error {
+	if err := os.MkdirAll(filepath.Dir(output), 0o755); err != nil {
+		return errors.Wrap(err, "cant create export directory")
+	}
+	if err := os.WriteFile(output, []byte(content), 0o644); err != nil {
+		return errors.Wrap(err, "cant write export")
+	}
+	return nil
+}
+
+// formatDep5 renders one Files stanza per authors and licence pair, with one
+// author per Copyright line, followed by a standalone License paragraph for
+// every licence used. Licences without a stored text are described by their
+// name and link. A Files stanza can't be empty, so credits without a file name
+// are only named in the header comment.
+func formatDep5(attribuitions []domain.Attribuition, texts map[string]string, request exportRequest) string {
+	type stanza struct {
+		files    []string
+		comments []string
+		authors  []string
+		licence  string
+	}
+	stanzas := make([]*stanza, 0)
+	byKey := make(map[string]*stanza)
+	licences := make(map[string]domain.Attribuition)
+	unlisted := make([]string, 0)
+	for _, attribuition := range attribuitions {
+		fileName := dep5FileName(attribuition.FileName)
+		if strings.TrimSpace(fileName) == "" {
+			unlisted = append(unlisted, attribuition.Name)
+			continue
+		}
+		id := exportSpdxId(attribuition.LicenceSpdx, attribuition.Licence)
+		authors := make([]string, 0, len(attribuition.Authors))
+		for _, author := range attribuition.Authors {
+			authors = append(authors, author.Name)
+		}
+		key := strings.Join(authors, "\n") + "\x00" + id
+		current, ok := byKey[key]
+		if !ok {
+			current = &stanza{authors: authors, licence: id}
+			byKey[key] = current
+			stanzas = append(stanzas, current)
+		}
+		current.files = append(current.files, fileName)
+		comment := attribuition.Name
+		if attribuition.Link != "" {
+			comment += " <" + attribuition.Link + ">"
+		}
+		current.comments = append(current.comments, comment)
+		licences[id] = attribuition
+	}
+
+	var builder strings.Builder
+	builder.WriteString("Format: " + dep5Format + "\n")
+	if request.UpstreamName != "" {
+		builder.WriteString("Upstream-Name: " + request.UpstreamName + "\n")
+	}
+	if request.Source != "" {
+		builder.WriteString("Source: " + request.Source + "\n")
+	}
+	if len(unlisted) > 0 {
+		builder.WriteString("Comment: Credits without a file name, not listed below:\n")
+		builder.WriteString(dep5Text(strings.Join(unlisted, "\n")))
+	}
+	for _, current := range stanzas {
+		copyright := strings.Join(current.authors, "\n ")
+		if copyright == "" {
+			copyright = dep5UnknownCopyright
+		}
+		builder.WriteString("\nFiles: " + strings.Join(current.files, "\n "))
+		builder.WriteString("\nCopyright: " + copyright)
+		builder.WriteString("\nLicense: " + current.licence)
+		builder.WriteString("\nComment: " + strings.Join(current.comments, "\n ") + "\n")
+	}
+
+	ids := make([]string, 0, len(licences))
+	for id := range licences {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+	for _, id := range ids {
+		builder.WriteString("\nLicense: " + id + "\n")
+		text := texts[licences[id].Licence]
+		if text == "" {
+			text = licences[id].Licence + "\n\n" + licences[id].LicenceUrl
+		}
+		builder.WriteString(dep5Text(text))
+	}
+	return builder.String()
+}
+
+// dep5FileName turns a Godot path into a DEP-5 pattern, spaces aren't allowed
+// in patterns so they are replaced by the single character wildcard.
+func dep5FileName(fileName string) string {
+	return strings.ReplaceAll(strings.TrimPrefix(fileName, godotResourcePrefix), " ", "?")
+}
+
+// dep5Text indents a text as a field continuation, empty lines become " .".
+func dep5Text(text string) string {
+	var builder strings.Builder
+	for _, line := range strings.Split(strings.TrimSpace(text), "\n") {
+		line = strings.TrimRight(line, " \t")
+		if line == "" {
+			line = "."
+		}
+		builder.WriteString(" " + line + "\n")
+	}
+	return builder.String()
+}
@@ -0,0 +1,175 @@
+package usecases
+
+import (
+	"encoding/json"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/pkg/errors"
+
+	"github.com/marcosbitetti/godot-manage-attribuitions-plugin/intrenal/domain"
+	"github.com/marcosbitetti/godot-manage-attribuitions-plugin/intrenal/infra"
+)
+
+const noticesRule = "================================================================================"
+
+type noticesRequest struct {
+	tagRules
+	Output    string `json:"output"`
+	Directory string `json:"directory"`
+	Title     string `json:"title"`
+	// Links are the kinds of links listed under each attribuition.
+	Links []string `json:"links"`
+}
+
+// licenceNotice groups the attribuitions covered by the same licence.
+type licenceNotice struct {
+	Id            string
+	Licence       string
+	LicenceUrl    string
+	Text          string
+	Attribuitions []domain.Attribuition
+	LinkKinds     []string
+}
+
+// ExportNotices writes a consolidated THIRD_PARTY_NOTICES file and/or a
+// directory with one text file per licence. Without output nor directory the
+// consolidated document is returned as data.
+func ExportNotices(storage *infra.Storage, args []string) []byte {
+	request := noticesRequest{}
+	if len(args) > 3 {
+		if err := json.Unmarshal([]byte(args[3]), &request); err != nil {
+			return FormatJSON(nil, errors.Wrap(err, "invalid export"))
+		}
+	}
+	if request.Title == "" {
+		request.Title = "THIRD-PARTY NOTICES"
+	}
+	for _, kind := range request.Links {
+		if !validLinkKind(kind) {
+			return FormatJSON(nil, NewErrInvalidValue())
+		}
+	}
+	attribuitions, err := storage.FindAttribuitions(request.query())
+	if err != nil {
+		return FormatJSON(nil, err)
+	}
+	notices := groupNotices(attribuitions, request.Links)
+	for _, notice := range notices {
+		licence, err := storage.FindLicence(notice.Licence)
+		if err != nil {
+			return FormatJSON(nil, err)
+		}
+		if licence != nil {
+			notice.Text = licence.Text
+		}
+	}
+
+	if request.Output == "" && request.Directory == "" {
+		return FormatJSON(formatNotices(request.Title, notices), nil)
+	}
+	if request.Output != "" {
+		if err := writeExport(request.Output, formatNotices(request.Title, notices)); err != nil {
+			return FormatJSON(nil, err)
+		}
+	}
+	if request.Directory != "" {
+		for _, notice := range notices {
+			output := filepath.Join(request.Directory, notice.Id+".txt")
+			if err := writeExport(output, formatNotice(notice)); err != nil {
+				return FormatJSON(nil, err)
+			}
+		}
+	}
+	return FormatJSON(SuccessMsg, nil)
+}
+
+func groupNotices(attribuitions []domain.Attribuition, linkKinds []string) []*licenceNotice {
+	byLicence := make(map[string]*licenceNotice)
+	for _, attribuition := range attribuitions {
+		notice, ok := byLicence[attribuition.Licence]
+		if !ok {
+			notice = &licenceNotice{
+				Id:         exportSpdxId(attribuition.LicenceSpdx, attribuition.Licence),
+				Licence:    attribuition.Licence,
+				LicenceUrl: attribuition.LicenceUrl,
+				LinkKinds:  linkKinds,
+			}
+			byLicence[attribuition.Licence] = notice
+		}
+		notice.Attribuitions = append(notice.Attribuitions, attribuition)
+	}
+	notices := make([]*licenceNotice, 0, len(byLicence))
+	for _, notice := range byLicence {
+		notices = append(notices, notice)
+	}
+	sort.Slice(notices, func(i, j int) bool {
+		return strings.ToLower(notices[i].Licence) < strings.ToLower(notices[j].Licence)
+	})
+	return notices
+}
+
+func formatNotices(title string, notices []*licenceNotice) string {
+	var builder strings.Builder
+	builder.WriteString(title + "\n\n")
+	builder.WriteString("This software includes third-party works distributed under the licences below.\n")
+	for _, notice := range notices {
+		builder.WriteString("\n" + formatNotice(notice))
+	}
+	return builder.String()
+}
+
+// formatNotice renders a licence followed by every attribuition it covers.
+func formatNotice(notice *licenceNotice) string {
+	var builder strings.Builder
+	builder.WriteString(noticesRule + "\n")
+	builder.WriteString(notice.Licence + "\n")
+	if notice.LicenceUrl != "" {
+		builder.WriteString(notice.LicenceUrl + "\n")
+	}
+	builder.WriteString(noticesRule + "\n\n")
+	builder.WriteString("Applies to:\n")
+	for _, attribuition := range notice.Attribuitions {
+		line := "  - " + attribuition.Name
+		if attribuition.Author != "" {
+			line += " by " + attribuition.Author
+		}
+		if attribuition.FileName != "" {
+			line += " (" + attribuition.FileName + ")"
+		}
+		builder.WriteString(line + "\n")
+		if attribuition.Link != "" {
+			builder.WriteString("    " + attribuition.Link + "\n")
+		}
+		for _, link := range attribuitionLinks(attribuition, notice.LinkKinds) {
+			if link.Url != attribuition.Link {
+				builder.WriteString("    " + linksLabel(link) + ": " + link.Url + "\n")
+			}
+		}
+		if attribuition.Modified {
+			builder.WriteString("    Modified from original")
+			if attribuition.UpstreamLink != "" {
+				builder.WriteString(" " + attribuition.UpstreamLink)
+			}
+			if attribuition.ModificationNotes != "" {
+				builder.WriteString(": " + attribuition.ModificationNotes)
+			}
+			builder.WriteString("\n")
+		}
+	}
+	builder.WriteString("\n" + noticeText(notice) + "\n")
+	return builder.String()
+}
+
+// noticeText is the licence text placed after the attribuitions list, the
+// link is only used for licences without a stored text.
+func noticeText(notice *licenceNotice) string {
+	if notice.Text != "" {
+		return strings.TrimRight(notice.Text, "\n")
+	}
+	if notice.LicenceUrl == "" {
+		return "The full text of this licence was not provided."
+	}
+	return "The full text of this licence is available at " + notice.LicenceUrl
+}
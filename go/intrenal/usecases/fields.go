@@ -0,0 +1,88 @@
+package usecases
+
+import (
+	"strings"
+
+	"github.com/pkg/errors"
+
+	"github.com/marcosbitetti/godot-manage-attribuitions-plugin/intrenal/domain"
+	"github.com/marcosbitetti/godot-manage-attribuitions-plugin/intrenal/infra"
+)
+
+// validateFields checks the custom field values of an attribuition against
+// their definitions. Names are matched ignoring case and renamed as defined,
+// null values are removed and the required fields must have a value.
+func validateFields(storage *infra.Storage, attribuition *domain.Attribuition) error {
+	definitions, err := storage.ListFields()
+	if err != nil {
+		return errors.Wrap(err, "error reading fields")
+	}
+	fields := make(map[string]interface{})
+	for name, value := range attribuition.Fields {
+		if value == nil {
+			continue
+		}
+		definition := findField(definitions, name)
+		if definition == nil || !validFieldValue(*definition, value) {
+			return NewErrInvalidValue()
+		}
+		fields[definition.Name] = value
+	}
+	for _, definition := range definitions {
+		if _, ok := fields[definition.Name]; definition.Required && !ok {
+			return NewErrInvalidValue()
+		}
+	}
+	attribuition.Fields = fields
+	return nil
+}
+
+func findField(definitions []domain.FieldDefinition, name string) *domain.FieldDefinition {
+	for i := range definitions {
+		if strings.EqualFold(definitions[i].Name, name) {
+			return &definitions[i]
+		}
+	}
+	return nil
+}
+
+func validFieldValue(definition domain.FieldDefinition, value interface{}) bool {
+	switch definition.Type {
+	case domain.FieldNumber:
+		_, ok := value.(float64)
+		return ok
+	case domain.FieldBool:
+		_, ok := value.(bool)
+		return ok
+	}
+	text, ok := value.(string)
+	if !ok || text == "" {
+		return false
+	}
+	switch definition.Type {
+	case domain.FieldDate:
+		return validDate(text)
+	case domain.FieldEnum:
+		for _, option := range definition.Options {
+			if text == option {
+				return true
+			}
+		}
+		return false
+	}
+	return true
+}
+
+// validFieldDefinition requires a name, a known type and the options of the
+// enum fields.
+func validFieldDefinition(definition domain.FieldDefinition) bool {
+	if strings.TrimSpace(definition.Name) == "" {
+		return false
+	}
+	for _, type_ := range domain.FieldTypes {
+		if definition.Type == type_ {
+			return definition.Type != domain.FieldEnum || len(definition.Options) > 0
+		}
+	}
+	return false
+}
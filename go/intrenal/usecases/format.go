@@ -6,23 +6,64 @@ import (
 
 const SuccessMsg = "done"
 
+// Created answers the add commands with the id of the new record, so batches
+// and scripts can refer to it.
+type Created struct {
+	Id int64 `json:"_id"`
+}
+
+const (
+	StatusSuccess = "success"
+	StatusFailure = "failure"
+	StatusError   = "error"
+)
+
 func FormatJSON(data interface{}, err error) []byte {
 	if err != nil {
-		return []byte(`{"status":"error", "message":"` + err.Error() + `"}`)
+		return formatError(err)
+	}
+	return formatResponse(StatusSuccess, data)
+}
+
+// formatError answers the error with its message escaped, messages may have
+// quotes, like the names of a seed pack.
+func formatError(err error) []byte {
+	message, _ := json.Marshal(err.Error())
+	return []byte(`{"status":"error", "message":` + string(message) + `}`)
+}
+
+// FormatFailure answers a command that ran fine but found problems, like a
+// compliance check with violations. The data is returned as usual.
+func FormatFailure(data interface{}) []byte {
+	return formatResponse(StatusFailure, data)
+}
+
+// Failed tells if a formatted response has the failure or error status. Plain
+// text responses, like the help, never fail.
+func Failed(response []byte) bool {
+	var parsed struct {
+		Status string `json:"status"`
 	}
+	if err := json.Unmarshal(response, &parsed); err != nil {
+		return false
+	}
+	return parsed.Status == StatusFailure || parsed.Status == StatusError
+}
+
+func formatResponse(status string, data interface{}) []byte {
 	type Response struct {
 		Status  string      `json:"status"`
 		Message *string     `json:"message,omitempty"`
 		Data    interface{} `json:"data"`
 	}
 	response := Response{
-		Status:  "success",
+		Status:  status,
 		Message: nil,
 		Data:    data,
 	}
 	bytes, err := json.Marshal(response)
 	if err != nil {
-		return []byte(`{"status":"error", "message":"` + err.Error() + `"}`)
+		return formatError(err)
 	}
 	return bytes
 }
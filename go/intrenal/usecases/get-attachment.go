@@ -0,0 +1,57 @@
+package usecases
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+
+	"github.com/pkg/errors"
+
+	"github.com/marcosbitetti/godot-manage-attribuitions-plugin/intrenal/infra"
+)
+
+// GetAttachment returns the description of an attachment and, when an
+// "output" path is informed, writes its content there.
+func GetAttachment(storage *infra.Storage, args []string) []byte {
+	if len(args) < 4 {
+		return FormatJSON(nil, NewErrMissingArgument())
+	}
+	var t attachmentRequest
+	if err := json.Unmarshal([]byte(args[3]), &t); err != nil {
+		return FormatJSON(nil, errors.Wrap(err, "invalid attachment"))
+	}
+	if t.Id == 0 {
+		return FormatJSON(nil, NewErrInvalidValue())
+	}
+	attachment, err := storage.GetAttachment(t.Id)
+	if err != nil {
+		return FormatJSON(nil, errors.Wrap(err, "error reading attachment"))
+	}
+	if attachment == nil {
+		return FormatJSON(nil, NewErrNotFound())
+	}
+	if t.Output != "" {
+		if err := writeAttachment(storage, attachment.Id, t.Output); err != nil {
+			return FormatJSON(nil, err)
+		}
+	}
+	return FormatJSON(attachment, nil)
+}
+
+func writeAttachment(storage *infra.Storage, id int64, output string) error {
+	if err := os.MkdirAll(filepath.Dir(output), 0o755); err != nil {
+		return errors.Wrap(err, "cant create attachment directory")
+	}
+	file, err := os.Create(output)
+	if err != nil {
+		return errors.Wrap(err, "cant create attachment file")
+	}
+	if err := storage.CopyAttachment(id, file); err != nil {
+		file.Close()
+		return err
+	}
+	if err := file.Close(); err != nil {
+		return errors.Wrap(err, "cant write attachment file")
+	}
+	return nil
+}
@@ -0,0 +1,41 @@
+package usecases
+
+import (
+	"encoding/json"
+
+	"github.com/pkg/errors"
+
+	"github.com/marcosbitetti/godot-manage-attribuitions-plugin/intrenal/domain"
+	"github.com/marcosbitetti/godot-manage-attribuitions-plugin/intrenal/infra"
+)
+
+// GetLicenceText returns the full text of a licence selected by id or name.
+func GetLicenceText(storage *infra.Storage, args []string) []byte {
+	if len(args) < 4 {
+		return FormatJSON(nil, NewErrMissingArgument())
+	}
+	var t domain.Licence
+	if err := json.Unmarshal([]byte(args[3]), &t); err != nil {
+		return FormatJSON(nil, errors.Wrap(err, "invalid type"))
+	}
+	if t.Id == 0 && t.Name == "" {
+		return FormatJSON(nil, NewErrInvalidValue())
+	}
+	var licence *domain.Licence
+	var err error
+	if t.Id != 0 {
+		licence, err = storage.GetLicence(t.Id)
+	} else {
+		licence, err = storage.FindLicence(t.Name)
+	}
+	if err != nil {
+		return FormatJSON(nil, errors.Wrap(err, "error reading licence"))
+	}
+	if licence == nil {
+		return FormatJSON(nil, NewErrNotFound())
+	}
+	if licence.Text == "" {
+		return FormatJSON(nil, errors.New("licence text not stored, see "+licence.Link))
+	}
+	return FormatJSON(licence.Text, nil)
+}
@@ -11,19 +11,115 @@ const staticHelp = `Usage:
 -> Attributions
 attribuitions-amd64-linux ~/mygames/attributions.sqlite listAttribuitions
 attribuitions-amd64-linux ~/mygames/attributions.sqlite listAttribuitions {"text":"<search>", "order": "ASC"}
+attribuitions-amd64-linux ~/mygames/attributions.sqlite listAttribuitions {"source":"Kenney"}
+attribuitions-amd64-linux ~/mygames/attributions.sqlite listAttribuitions {"tags":["dlc1"], "excludeTags":["marketing-only"]}
+attribuitions-amd64-linux ~/mygames/attributions.sqlite listAttribuitions {"fields":{"region":"EU"}}
+attribuitions-amd64-linux ~/mygames/attributions.sqlite listAttribuitions {"type":"Audio"}
 attribuitions-amd64-linux ~/mygames/attributions.sqlite addAttribuition {"name":"Test","filename":"file","type":"One","author":"Ze","link":"http://none","licence":"MIT","type":"Music"}
 attribuitions-amd64-linux ~/mygames/attributions.sqlite updateAttribuition {"_id":1,"name":"_Test","filename":"_file","type":"_One","author":"_Ze","link":"_http://none","licence":"Beerware","type":"Plugin"}
+attribuitions-amd64-linux ~/mygames/attributions.sqlite updateAttribuition {"_id":1,"name":"_Test","filename":"_file","type":"_One","author":"_Ze","link":"_http://none","licence":"Beerware","type":"Plugin","attributionOverride":"Music by {author}"}
+attribuitions-amd64-linux ~/mygames/attributions.sqlite updateAttribuition {"_id":1,"name":"_Test","filename":"_file","type":"_One","author":"_Ze","link":"_http://none","licence":"Beerware","type":"Plugin","modified":true,"modificationNotes":"recoloured","upstreamLink":"https://example.com/original.png"}
+attribuitions-amd64-linux ~/mygames/attributions.sqlite addAttribuition {"name":"Test","filename":"file","type":"Music","authors":[{"name":"Ze"},{"_id":2}],"link":"http://none","licence":"MIT"}
+attribuitions-amd64-linux ~/mygames/attributions.sqlite addAttribuition {"name":"Test","filename":"file","type":"Music","author":"Ze","link":"http://none","licence":"MIT","fields":{"assetId":"MUS-042","region":"EU"}}
+attribuitions-amd64-linux ~/mygames/attributions.sqlite updateAttribuition {"_id":1,"name":"_Test","filename":"_file","author":"_Ze","link":"_http://none","licence":"Beerware","type":"Plugin","purchase":{"price":19.99, "currency":"USD", "date":"2024-03-10", "orderId":"INV-0042", "seats":2, "account":"studio@example.com"}}
+attribuitions-amd64-linux ~/mygames/attributions.sqlite updateAttribuition {"_id":1,"name":"_Test","filename":"_file","author":"_Ze","link":"_http://none","licence":"Beerware","type":"Plugin","validUntil":"2025-12-31","renewBy":"2025-12-01"}
+attribuitions-amd64-linux ~/mygames/attributions.sqlite upsertAttribuition {"name":"Rain","filename":"sfx/rain.ogg","type":"Music","author":"Ze","link":"http://none","licence":"MIT"}
+attribuitions-amd64-linux ~/mygames/attributions.sqlite upsertAttribuition {"key":"externalId","externalId":"SFX-012","name":"Rain","type":"Music","author":"Ze","link":"http://none","licence":"MIT"}
+attribuitions-amd64-linux ~/mygames/attributions.sqlite bulkUpdateAttribuitions {"query":{"text":"Ze"},"changes":{"licence":"MIT"},"dryRun":true}
+attribuitions-amd64-linux ~/mygames/attributions.sqlite bulkUpdateAttribuitions {"query":{"type":"Music"},"changes":{"type":"Sound Effect"}}
+attribuitions-amd64-linux ~/mygames/attributions.sqlite expiringLicences {"days":30}
 attribuitions-amd64-linux ~/mygames/attributions.sqlite deleteAttribuition {"_id":1}
+attribuitions-amd64-linux ~/mygames/attributions.sqlite addLink {"credit":1, "kind":"donate", "url":"https://www.patreon.com/someone", "label":"Patreon"}
+attribuitions-amd64-linux ~/mygames/attributions.sqlite removeLink {"_id":1}
+
+-> Authors
+attribuitions-amd64-linux ~/mygames/attributions.sqlite listAuthors
+attribuitions-amd64-linux ~/mygames/attributions.sqlite addAuthor {"name":"Kenney", "aliases":["kenney.nl"], "homepage":"https://kenney.nl", "contact":"", "social":["@KenneyNL"]}
+attribuitions-amd64-linux ~/mygames/attributions.sqlite updateAuthor {"_id":1, "name":"Kenney Vleugels"}
+attribuitions-amd64-linux ~/mygames/attributions.sqlite deleteAuthor {"_id":1}
+attribuitions-amd64-linux ~/mygames/attributions.sqlite mergeAuthors {"into":1, "from":[2,3]}
+
+-> Sources
+attribuitions-amd64-linux ~/mygames/attributions.sqlite listSources
+attribuitions-amd64-linux ~/mygames/attributions.sqlite addSource {"name":"Poly Haven", "baseUrl":"https://polyhaven.com", "defaultLicence":"CC0 1.0 Universal (CC0 1.0) - Public Domain Dedication"}
+attribuitions-amd64-linux ~/mygames/attributions.sqlite updateSource {"_id":1, "defaultLicence":"MIT"}
+attribuitions-amd64-linux ~/mygames/attributions.sqlite deleteSource {"_id":1}
+attribuitions-amd64-linux ~/mygames/attributions.sqlite sourceReport
+attribuitions-amd64-linux ~/mygames/attributions.sqlite suggestLicence {"link":"https://kenney.nl/assets/space-kit"}
+attribuitions-amd64-linux ~/mygames/attributions.sqlite purchaseReport
+
+-> Permissions
+attribuitions-amd64-linux ~/mygames/attributions.sqlite listPermissions {"credit":1}
+attribuitions-amd64-linux ~/mygames/attributions.sqlite addPermission {"credit":1, "grantor":"Jane Doe", "grantedAt":"2024-05-02", "channel":"email", "scope":"this game only", "commercial":true, "derivatives":false, "text":"<grant text>", "proof":"permissions/jane-doe.eml"}
+attribuitions-amd64-linux ~/mygames/attributions.sqlite updatePermission {"_id":1, "derivatives":true}
+attribuitions-amd64-linux ~/mygames/attributions.sqlite deletePermission {"_id":1}
+
+-> Attachments
+attribuitions-amd64-linux ~/mygames/attributions.sqlite listAttachments {"credit":1}
+attribuitions-amd64-linux ~/mygames/attributions.sqlite addAttachment {"credit":1, "path":"~/Downloads/receipt.pdf"}
+attribuitions-amd64-linux ~/mygames/attributions.sqlite addAttachment {"credit":1, "path":"~/Downloads/pack/LICENSE.txt", "name":"pack-LICENSE.txt", "mimeType":"text/plain"}
+attribuitions-amd64-linux ~/mygames/attributions.sqlite getAttachment {"_id":1, "output":"proof/receipt.pdf"}
+attribuitions-amd64-linux ~/mygames/attributions.sqlite removeAttachment {"_id":1}
+
+-> Tags
+attribuitions-amd64-linux ~/mygames/attributions.sqlite listTags
+attribuitions-amd64-linux ~/mygames/attributions.sqlite tag {"credit":1, "tags":["placeholder","dlc1"]}
+attribuitions-amd64-linux ~/mygames/attributions.sqlite untag {"credit":1, "tags":["placeholder"]}
+
+-> Custom fields
+attribuitions-amd64-linux ~/mygames/attributions.sqlite listFields
+attribuitions-amd64-linux ~/mygames/attributions.sqlite addField {"name":"assetId", "type":"string", "required":true}
+attribuitions-amd64-linux ~/mygames/attributions.sqlite addField {"name":"region", "type":"enum", "options":["EU","US","Worldwide"]}
+attribuitions-amd64-linux ~/mygames/attributions.sqlite updateField {"_id":1, "required":false}
+attribuitions-amd64-linux ~/mygames/attributions.sqlite deleteField {"_id":1}
+
+-> Seed packs
+attribuitions-amd64-linux ~/mygames/newgame.sqlite init
+attribuitions-amd64-linux ~/mygames/newgame.sqlite init --seed licences --seed types-pt-BR --seed ~/studio/studio-defaults.json
+attribuitions-amd64-linux ~/mygames/attributions.sqlite listSeeds
+attribuitions-amd64-linux ~/mygames/attributions.sqlite seed {"pack":"types"}
+attribuitions-amd64-linux ~/mygames/attributions.sqlite seed {"pack":"~/studio/studio-defaults.yaml"}
 
 -> Types
 attribuitions-amd64-linux ~/mygames/attributions.sqlite listTypes
+attribuitions-amd64-linux ~/mygames/attributions.sqlite listTypes {"tree":true}
 attribuitions-amd64-linux ~/mygames/attributions.sqlite addType {"name": "Font"}
+attribuitions-amd64-linux ~/mygames/attributions.sqlite addType {"name": "Ambient", "parent":2}
 attribuitions-amd64-linux ~/mygames/attributions.sqlite updateType {"_id":1, "name": "FontNew"}
+attribuitions-amd64-linux ~/mygames/attributions.sqlite updateType {"_id":2, "parent":12}
+attribuitions-amd64-linux ~/mygames/attributions.sqlite addType {"name": "Meme", "defaultLicence":"MIT", "requires":["author","filename"]}
 attribuitions-amd64-linux ~/mygames/attributions.sqlite deleteType {"_id":1}
+attribuitions-amd64-linux ~/mygames/attributions.sqlite mergeTypes {"into":3, "from":[7,8]}
 
 -> Licenses
 attribuitions-amd64-linux ~/mygames/attributions.sqlite listLicences
 attribuitions-amd64-linux ~/mygames/attributions.sqlite addLicence {"name": "Insaneware", "link": "https://example.com/license"}
+attribuitions-amd64-linux ~/mygames/attributions.sqlite addLicence {"spdx": "OFL-1.1"}
 attribuitions-amd64-linux ~/mygames/attributions.sqlite updateLicence {"_id":1, "name": "Insaneware2", "link": "https://example.com/licenses"}
+attribuitions-amd64-linux ~/mygames/attributions.sqlite updateLicence {"_id":1, "name": "Insaneware2", "link": "https://example.com/licenses", "text": "<full text>", "summary": "<short summary>"}
+attribuitions-amd64-linux ~/mygames/attributions.sqlite updateLicence {"_id":1, "name": "Insaneware2", "link": "https://example.com/licenses", "allowsCommercial": false, "shareAlike": true, "copyleftScope": "derivative"}
+attribuitions-amd64-linux ~/mygames/attributions.sqlite updateLicence {"_id":1, "name": "Insaneware2", "link": "https://example.com/licenses", "attributionTemplate": "{title} by {author}, used under {licence}"}
 attribuitions-amd64-linux ~/mygames/attributions.sqlite deleteLicence {"_id":1}
+attribuitions-amd64-linux ~/mygames/attributions.sqlite mergeLicences {"into":1, "from":[24,25]}
+attribuitions-amd64-linux ~/mygames/attributions.sqlite getLicenceText {"_id":1}
+attribuitions-amd64-linux ~/mygames/attributions.sqlite getLicenceText {"name":"MIT"}
+attribuitions-amd64-linux ~/mygames/attributions.sqlite matchSpdx
+
+-> Batch
+attribuitions-amd64-linux ~/mygames/attributions.sqlite batch [{"command":"addType","payload":{"name":"Ambient"},"as":"ambient"},{"command":"addAttribuition","payload":{"name":"Rain","type":"Ambient","author":"Ana","link":"https://example.com/rain","licence":"MIT"},"as":"rain"},{"command":"addLink","payload":{"credit":"$rain","kind":"donate","url":"https://example.com/tip"}}]
+
+-> Import / Export
+attribuitions-amd64-linux ~/mygames/attributions.sqlite importReuse {"path":"~/mygames/mygame", "type":"Texture"}
+attribuitions-amd64-linux ~/mygames/attributions.sqlite exportDep5 {"output":"debian/copyright", "upstreamName":"My Game", "source":"https://example.com/mygame"}
+attribuitions-amd64-linux ~/mygames/attributions.sqlite importDep5 {"path":"debian/copyright", "type":"Texture"}
+attribuitions-amd64-linux ~/mygames/attributions.sqlite exportNotices {"output":"export/THIRD_PARTY_NOTICES.txt", "directory":"export/licences"}
+attribuitions-amd64-linux ~/mygames/attributions.sqlite exportCredits {"output":"export/CREDITS.md", "format":"markdown", "title":"Credits"}
+attribuitions-amd64-linux ~/mygames/attributions.sqlite exportCredits {"format":"html", "groupBy":"author", "links":["source","donate"]}
+attribuitions-amd64-linux ~/mygames/attributions.sqlite exportCredits {"format":"markdown", "groupBy":"type"}
+attribuitions-amd64-linux ~/mygames/attributions.sqlite exportCredits {"includeTags":["dlc1"], "excludeTags":["placeholder","marketing-only"]}
+
+-> Project
+attribuitions-amd64-linux ~/mygames/attributions.sqlite getProfile
+attribuitions-amd64-linux ~/mygames/attributions.sqlite updateProfile {"commercial":true, "licence":"", "platforms":["Steam","Web"]}
+attribuitions-amd64-linux ~/mygames/attributions.sqlite checkCompliance
 `
@@ -0,0 +1,136 @@
+package usecases
+
+import (
+	"encoding/json"
+	"os"
+	"regexp"
+	"strings"
+
+	"github.com/pkg/errors"
+
+	"github.com/marcosbitetti/godot-manage-attribuitions-plugin/intrenal/domain"
+	"github.com/marcosbitetti/godot-manage-attribuitions-plugin/intrenal/infra"
+)
+
+var firstUrl = regexp.MustCompile(`https?://[^\s>)]+`)
+
+// ImportDep5 reads a machine-readable debian/copyright file back into the
+// database. Licences missing in the database are created from the SPDX catalog
+// with the text of the standalone License paragraphs; wildcard patterns only
+// complete existing attribuitions.
+func ImportDep5(storage *infra.Storage, args []string) []byte {
+	if len(args) < 4 {
+		return FormatJSON(nil, NewErrMissingArgument())
+	}
+	var request importRequest
+	if err := json.Unmarshal([]byte(args[3]), &request); err != nil {
+		return FormatJSON(nil, errors.Wrap(err, "invalid import"))
+	}
+	if request.Path == "" {
+		return FormatJSON(nil, NewErrInvalidValue())
+	}
+	content, err := os.ReadFile(request.Path)
+	if err != nil {
+		return FormatJSON(nil, errors.Wrap(err, "cant read copyright file"))
+	}
+
+	paragraphs := parseDeb822(string(content))
+	if err := addMissingDep5Licences(storage, paragraphs); err != nil {
+		return FormatJSON(nil, errors.Wrap(err, "error adding licences"))
+	}
+	attribuitions, err := storage.FindAttribuitions(domain.Query{Order: "ASC"})
+	if err != nil {
+		return FormatJSON(nil, err)
+	}
+
+	findings := make(map[string]*reuseFinding)
+	unmatched := make([]domain.ImportConflict, 0)
+	for _, paragraph := range paragraphs {
+		if paragraph["files"] == "" || paragraph["files"] == "*" {
+			continue
+		}
+		licence := strings.SplitN(paragraph["license"], "\n", 2)[0]
+		for _, pattern := range strings.Fields(paragraph["files"]) {
+			finding := &reuseFinding{
+				Copyrights: dep5Copyrights(paragraph),
+				Licence:    licence,
+				Source:     "Files: " + pattern,
+			}
+			if !strings.ContainsAny(pattern, "*?") {
+				overrideFinding(findings, pattern, finding)
+				continue
+			}
+			matcher := globToRegexp(pattern, true)
+			matched := false
+			for _, attribuition := range attribuitions {
+				file := strings.TrimPrefix(attribuition.FileName, godotResourcePrefix)
+				if matcher.MatchString(file) {
+					matched = true
+					overrideFinding(findings, file, finding)
+				}
+			}
+			if !matched {
+				unmatched = append(unmatched, domain.ImportConflict{
+					FileName: pattern,
+					Field:    "files",
+					Found:    pattern,
+					Source:   finding.Source,
+				})
+			}
+		}
+	}
+
+	report, err := mergeFindings(storage, findings, request.Type)
+	if err != nil {
+		return FormatJSON(nil, errors.Wrap(err, "error importing attribuitions"))
+	}
+	report.Conflicts = append(report.Conflicts, unmatched...)
+	return FormatJSON(report, nil)
+}
+
+// dep5Copyrights returns the copyright lines of a paragraph, without the
+// placeholder written for the attribuitions without authors.
+func dep5Copyrights(paragraph deb822Paragraph) []string {
+	copyrights := make([]string, 0)
+	for _, line := range paragraph.lines("copyright") {
+		if !strings.EqualFold(line, dep5UnknownCopyright) {
+			copyrights = append(copyrights, line)
+		}
+	}
+	return copyrights
+}
+
+func addMissingDep5Licences(storage *infra.Storage, paragraphs []deb822Paragraph) error {
+	licences, err := storage.ListLicences()
+	if err != nil {
+		return err
+	}
+	for _, paragraph := range paragraphs {
+		if paragraph["files"] != "" || paragraph["license"] == "" {
+			continue
+		}
+		parts := strings.SplitN(paragraph["license"], "\n", 2)
+		id := strings.TrimSpace(parts[0])
+		if findLicenceBySpdx(licences, id) != nil {
+			continue
+		}
+		licence := infra.FindSpdxLicence(id)
+		if licence == nil {
+			licence = &domain.Licence{Name: strings.TrimPrefix(id, "LicenseRef-"), LicenceTerms: domain.NewLicenceTerms()}
+			if strings.HasPrefix(id, "LicenseRef-") {
+				licence.SpdxId = id
+			}
+		}
+		if len(parts) > 1 {
+			licence.Text = strings.TrimSpace(parts[1])
+			if link := firstUrl.FindString(licence.Text); link != "" && licence.Link == "" {
+				licence.Link = link
+			}
+		}
+		if _, err := storage.AddLicence(*licence); err != nil {
+			return err
+		}
+		licences = append(licences, *licence)
+	}
+	return nil
+}
@@ -0,0 +1,272 @@
+package usecases
+
+import (
+	"encoding/json"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/pkg/errors"
+
+	"github.com/marcosbitetti/godot-manage-attribuitions-plugin/intrenal/domain"
+	"github.com/marcosbitetti/godot-manage-attribuitions-plugin/intrenal/infra"
+)
+
+const godotResourcePrefix = "res://"
+
+// typeByExtension suggests a type for imported files, the payload "type" is
+// used for anything not listed here.
+var typeByExtension = map[string]string{
+	".gd":       "Code Snippet",
+	".cs":       "Code Snippet",
+	".gdshader": "Shader",
+	".glb":      "3D Model",
+	".gltf":     "3D Model",
+	".obj":      "3D Model",
+	".fbx":      "3D Model",
+	".blend":    "3D Model",
+	".png":      "Texture",
+	".jpg":      "Texture",
+	".jpeg":     "Texture",
+	".webp":     "Texture",
+	".svg":      "Texture",
+	".ogg":      "Sound Effect",
+	".wav":      "Sound Effect",
+	".mp3":      "Music",
+	".ttf":      "Font",
+	".otf":      "Font",
+	".woff":     "Font",
+	".woff2":    "Font",
+}
+
+type importRequest struct {
+	Path string `json:"path"`
+	Type string `json:"type"`
+}
+
+func ImportReuse(storage *infra.Storage, args []string) []byte {
+	if len(args) < 4 {
+		return FormatJSON(nil, NewErrMissingArgument())
+	}
+	var request importRequest
+	if err := json.Unmarshal([]byte(args[3]), &request); err != nil {
+		return FormatJSON(nil, errors.Wrap(err, "invalid import"))
+	}
+	if request.Path == "" {
+		return FormatJSON(nil, NewErrInvalidValue())
+	}
+
+	findings, err := collectReuseFindings(request.Path)
+	if err != nil {
+		return FormatJSON(nil, errors.Wrap(err, "error reading REUSE metadata"))
+	}
+	report, err := mergeFindings(storage, findings, request.Type)
+	if err != nil {
+		return FormatJSON(nil, errors.Wrap(err, "error importing attribuitions"))
+	}
+	return FormatJSON(report, nil)
+}
+
+// collectReuseFindings gathers the information of every file of a project. Later
+// sources take precedence: .reuse/dep5, REUSE.toml, then sidecars and headers.
+func collectReuseFindings(root string) (map[string]*reuseFinding, error) {
+	files, err := listProjectFiles(root)
+	if err != nil {
+		return nil, err
+	}
+	findings := make(map[string]*reuseFinding)
+
+	if content, err := os.ReadFile(filepath.Join(root, ".reuse", "dep5")); err == nil {
+		for _, paragraph := range parseDeb822(string(content)) {
+			if paragraph["files"] == "" {
+				continue
+			}
+			annotation := reuseAnnotation{
+				Paths:      strings.Fields(paragraph["files"]),
+				Copyrights: paragraph.lines("copyright"),
+				Licence:    strings.SplitN(paragraph["license"], "\n", 2)[0],
+			}
+			applyAnnotation(findings, files, annotation, true, ".reuse/dep5")
+		}
+	} else if !errors.Is(err, os.ErrNotExist) {
+		return nil, errors.Wrap(err, "cant read .reuse/dep5")
+	}
+
+	if content, err := os.ReadFile(filepath.Join(root, "REUSE.toml")); err == nil {
+		annotations, err := parseReuseToml(string(content))
+		if err != nil {
+			return nil, err
+		}
+		for _, annotation := range annotations {
+			applyAnnotation(findings, files, annotation, false, "REUSE.toml")
+		}
+	} else if !errors.Is(err, os.ErrNotExist) {
+		return nil, errors.Wrap(err, "cant read REUSE.toml")
+	}
+
+	for _, file := range files {
+		sources := []string{file + ".license"}
+		if reuseHeaderExtensions[strings.ToLower(path.Ext(file))] {
+			sources = append(sources, file)
+		}
+		for _, source := range sources {
+			full := filepath.Join(root, filepath.FromSlash(source))
+			if _, err := os.Stat(full); err != nil {
+				continue
+			}
+			finding, err := readSpdxTags(full)
+			if err != nil {
+				return nil, err
+			}
+			if finding == nil {
+				continue
+			}
+			finding.Source = source
+			overrideFinding(findings, file, finding)
+		}
+	}
+	return findings, nil
+}
+
+func applyAnnotation(findings map[string]*reuseFinding, files []string, annotation reuseAnnotation, crossDirs bool, source string) {
+	for _, glob := range annotation.Paths {
+		matcher := globToRegexp(glob, crossDirs)
+		for _, file := range files {
+			if !matcher.MatchString(file) {
+				continue
+			}
+			overrideFinding(findings, file, &reuseFinding{
+				Copyrights: annotation.Copyrights,
+				Licence:    annotation.Licence,
+				Source:     source,
+			})
+		}
+	}
+}
+
+// overrideFinding replaces the fields informed by a finding with higher precedence.
+func overrideFinding(findings map[string]*reuseFinding, file string, finding *reuseFinding) {
+	current, ok := findings[file]
+	if !ok {
+		findings[file] = finding
+		return
+	}
+	if len(finding.Copyrights) > 0 {
+		current.Copyrights = finding.Copyrights
+	}
+	if finding.Licence != "" {
+		current.Licence = finding.Licence
+	}
+	current.Source = finding.Source
+}
+
+// mergeFindings creates the attribuitions for new files and completes the
+// existing ones. Divergent values are reported as conflicts, never overwritten.
+func mergeFindings(storage *infra.Storage, findings map[string]*reuseFinding, defaultType string) (*domain.ImportReport, error) {
+	report := domain.NewImportReport()
+	licences, err := storage.ListLicences()
+	if err != nil {
+		return nil, err
+	}
+	types, err := storage.ListTypes()
+	if err != nil {
+		return nil, err
+	}
+	attribuitions, err := storage.FindAttribuitions(domain.Query{Order: "ASC"})
+	if err != nil {
+		return nil, err
+	}
+
+	files := make([]string, 0, len(findings))
+	for file := range findings {
+		files = append(files, file)
+	}
+	sort.Strings(files)
+
+	for _, file := range files {
+		finding := findings[file]
+		fileName := godotResourcePrefix + file
+		licence := findLicenceBySpdx(licences, finding.Licence)
+		if licence == nil {
+			report.Conflicts = append(report.Conflicts, domain.ImportConflict{
+				FileName: fileName,
+				Field:    "licence",
+				Found:    finding.Licence,
+				Source:   finding.Source,
+			})
+			continue
+		}
+		author := authorFromCopyrights(finding.Copyrights)
+
+		existing := findAttribuitionByFile(attribuitions, fileName)
+		if existing == nil {
+			typeName := guessType(types, file, defaultType)
+			attribuition := domain.Attribuition{
+				Name:     path.Base(file),
+				FileName: fileName,
+				Author:   author,
+				Type:     typeName,
+				Licence:  licence.Name,
+			}
+			if _, err := storage.AddAttribuition(attribuition); err != nil {
+				return nil, err
+			}
+			report.Created = append(report.Created, fileName)
+			continue
+		}
+
+		conflicted := false
+		if existing.Licence != licence.Name {
+			conflicted = true
+			report.Conflicts = append(report.Conflicts, domain.ImportConflict{
+				FileName: fileName, Field: "licence", Current: existing.Licence, Found: licence.Name, Source: finding.Source,
+			})
+		}
+		if existing.Author != "" && author != "" && existing.Author != author {
+			conflicted = true
+			report.Conflicts = append(report.Conflicts, domain.ImportConflict{
+				FileName: fileName, Field: "author", Current: existing.Author, Found: author, Source: finding.Source,
+			})
+		}
+		if conflicted {
+			continue
+		}
+		if existing.Author == "" && author != "" {
+			existing.Author = author
+			if err := storage.UpdateAttribuition(*existing); err != nil {
+				return nil, err
+			}
+			report.Merged = append(report.Merged, fileName)
+			continue
+		}
+		report.Unchanged = append(report.Unchanged, fileName)
+	}
+	return report, nil
+}
+
+func findAttribuitionByFile(list []domain.Attribuition, fileName string) *domain.Attribuition {
+	wanted := strings.TrimPrefix(fileName, godotResourcePrefix)
+	for i := range list {
+		if strings.TrimPrefix(list[i].FileName, godotResourcePrefix) == wanted {
+			return &list[i]
+		}
+	}
+	return nil
+}
+
+func guessType(types []domain.Type, file string, defaultType string) string {
+	candidates := []string{typeByExtension[strings.ToLower(path.Ext(file))], defaultType}
+	for _, candidate := range candidates {
+		for _, t := range types {
+			if candidate != "" && strings.EqualFold(t.Name, candidate) {
+				return t.Name
+			}
+		}
+	}
+	if len(types) > 0 {
+		return types[0].Name
+	}
+	return defaultType
+}
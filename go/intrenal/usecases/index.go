@@ -16,19 +16,66 @@ import "github.com/marcosbitetti/godot-manage-attribuitions-plugin/intrenal/infr
 //   - Flexibility: The map can be iterated, inspected, or even modified at runtime if needed, enabling dynamic command registration.
 //   - Decoupling: Command logic is decoupled from the command parsing logic, promoting separation of concerns and cleaner code organization.
 var commands = map[string]func(storage *infra.Storage, args []string) []byte {
-	"help":               GetHelp,
-	"listAttribuitions":  GetAttribuitions,
-	"listTypes":          GetTypes,
-	"listLicences":       GetLicences,
-	"addType":            AddType,
-	"addLicence":         AddLicence,
-	"updateType":         UpdateType,
-	"deleteType":         DeleteType,
-	"updateLicence":      UpdateLicence,
-	"deleteLicence":      DeleteLicence,
-	"addAttribuition":    AddAttribuition,
-	"updateAttribuition": UpdateAttribuition,
-	"deleteAttribuition": DeleteAttribuition,
+	"help":                    GetHelp,
+	"listAttribuitions":       GetAttribuitions,
+	"listTypes":               GetTypes,
+	"listLicences":            GetLicences,
+	"addType":                 AddType,
+	"addLicence":              AddLicence,
+	"updateType":              UpdateType,
+	"deleteType":              DeleteType,
+	"updateLicence":           UpdateLicence,
+	"deleteLicence":           DeleteLicence,
+	"getLicenceText":          GetLicenceText,
+	"matchSpdx":               MatchSpdx,
+	"addAttribuition":         AddAttribuition,
+	"updateAttribuition":      UpdateAttribuition,
+	"upsertAttribuition":      UpsertAttribuition,
+	"bulkUpdateAttribuitions": BulkUpdateAttribuitions,
+	"deleteAttribuition":      DeleteAttribuition,
+	"addLink":                 AddLink,
+	"removeLink":              RemoveLink,
+	"listAuthors":             GetAuthors,
+	"addAuthor":               AddAuthor,
+	"updateAuthor":            UpdateAuthor,
+	"deleteAuthor":            DeleteAuthor,
+	"mergeAuthors":            MergeAuthors,
+	"mergeLicences":           MergeLicences,
+	"mergeTypes":              MergeTypes,
+	"listSources":             GetSources,
+	"addSource":               AddSource,
+	"updateSource":            UpdateSource,
+	"deleteSource":            DeleteSource,
+	"sourceReport":            SourceReport,
+	"suggestLicence":          SuggestLicence,
+	"purchaseReport":          PurchaseReport,
+	"expiringLicences":        ExpiringLicences,
+	"listPermissions":         GetPermissions,
+	"addPermission":           AddPermission,
+	"updatePermission":        UpdatePermission,
+	"deletePermission":        DeletePermission,
+	"listAttachments":         GetAttachments,
+	"addAttachment":           AddAttachment,
+	"getAttachment":           GetAttachment,
+	"removeAttachment":        RemoveAttachment,
+	"listTags":                GetTags,
+	"tag":                     Tag,
+	"untag":                   Untag,
+	"listFields":              GetFields,
+	"addField":                AddField,
+	"updateField":             UpdateField,
+	"deleteField":             DeleteField,
+	"init":                    Init,
+	"seed":                    Seed,
+	"listSeeds":               GetSeeds,
+	"importReuse":             ImportReuse,
+	"exportDep5":              ExportDep5,
+	"importDep5":              ImportDep5,
+	"exportNotices":           ExportNotices,
+	"exportCredits":           ExportCredits,
+	"getProfile":              GetProfile,
+	"updateProfile":           UpdateProfile,
+	"checkCompliance":         CheckCompliance,
 }
 
 func Commands() map[string]func(storage *infra.Storage, args []string) []byte {
@@ -0,0 +1,80 @@
+package usecases
+
+import (
+	"strings"
+
+	"github.com/marcosbitetti/godot-manage-attribuitions-plugin/intrenal/domain"
+)
+
+func validLinkKind(kind string) bool {
+	for _, valid := range domain.LinkKinds {
+		if kind == valid {
+			return true
+		}
+	}
+	return false
+}
+
+// attribuitionLinks returns the links of the given kinds, in the LinkKinds
+// order. The attribuition link is a source link, the upstream link an upstream
+// one and the author homepages are author links.
+func attribuitionLinks(attribuition domain.Attribuition, kinds []string) []domain.Link {
+	all := make([]domain.Link, 0)
+	if isUrl(attribuition.Link) {
+		all = append(all, domain.Link{Kind: domain.LinkSource, Url: attribuition.Link})
+	}
+	if attribuition.UpstreamLink != "" {
+		all = append(all, domain.Link{Kind: domain.LinkUpstream, Url: attribuition.UpstreamLink})
+	}
+	for _, author := range attribuition.Authors {
+		if author.Homepage != "" {
+			all = append(all, domain.Link{Kind: domain.LinkAuthor, Url: author.Homepage, Label: author.Name})
+		}
+	}
+	all = append(all, attribuition.Links...)
+
+	list := make([]domain.Link, 0)
+	seen := make(map[string]bool)
+	for _, kind := range domain.LinkKinds {
+		wanted := false
+		for _, item := range kinds {
+			wanted = wanted || item == kind
+		}
+		if !wanted {
+			continue
+		}
+		for _, link := range all {
+			if link.Kind != kind || seen[kind+"\x00"+link.Url] {
+				continue
+			}
+			seen[kind+"\x00"+link.Url] = true
+			list = append(list, link)
+		}
+	}
+	return list
+}
+
+// linksLabel is the text shown for a link, its label or its kind.
+func linksLabel(link domain.Link) string {
+	if link.Label != "" {
+		return link.Label
+	}
+	return link.Kind
+}
+
+// formatLinks renders links to append to an attribution text.
+func formatLinks(links []domain.Link, format string) string {
+	if len(links) == 0 {
+		return ""
+	}
+	m := markups[format]
+	parts := make([]string, 0, len(links))
+	for _, link := range links {
+		if format == FormatPlain {
+			parts = append(parts, linksLabel(link)+": "+link.Url)
+			continue
+		}
+		parts = append(parts, m.link(linksLabel(link), link.Url))
+	}
+	return " · " + strings.Join(parts, " · ")
+}
@@ -0,0 +1,22 @@
+package usecases
+
+import (
+	"encoding/json"
+
+	"github.com/pkg/errors"
+
+	"github.com/marcosbitetti/godot-manage-attribuitions-plugin/intrenal/infra"
+)
+
+// GetAttachments lists the attachments of the attribuition with the "credit"
+// id, or every attachment when it is omitted.
+func GetAttachments(storage *infra.Storage, args []string) []byte {
+	var t attachmentRequest
+	if len(args) > 3 {
+		if err := json.Unmarshal([]byte(args[3]), &t); err != nil {
+			return FormatJSON(nil, errors.Wrap(err, "invalid attachment"))
+		}
+	}
+	return FormatJSON(storage.ListAttachments(t.Credit))
+
+}
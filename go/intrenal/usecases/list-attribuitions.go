@@ -18,9 +18,10 @@ func GetAttribuitions(storage *infra.Storage, args []string) []byte {
 		return FormatJSON(nil, err)
 	}
 
-	attribuitions, err := storage.FindAttribuitions(query.Order, query.Text)
+	attribuitions, err := storage.FindAttribuitions(*query)
 	if err != nil {
 		return FormatJSON(nil, err)
 	}
+	fillAttributionTexts(attribuitions)
 	return FormatJSON(attribuitions, nil)
 }
@@ -0,0 +1,10 @@
+package usecases
+
+import (
+	"github.com/marcosbitetti/godot-manage-attribuitions-plugin/intrenal/infra"
+)
+
+func GetAuthors(storage *infra.Storage, _ []string) []byte {
+	return FormatJSON(storage.ListAuthors())
+
+}
@@ -0,0 +1,23 @@
+package usecases
+
+import (
+	"encoding/json"
+
+	"github.com/pkg/errors"
+
+	"github.com/marcosbitetti/godot-manage-attribuitions-plugin/intrenal/domain"
+	"github.com/marcosbitetti/godot-manage-attribuitions-plugin/intrenal/infra"
+)
+
+// GetPermissions lists the permissions granted for the attribuition with the
+// "credit" id, or every permission when it is omitted.
+func GetPermissions(storage *infra.Storage, args []string) []byte {
+	var t domain.Permission
+	if len(args) > 3 {
+		if err := json.Unmarshal([]byte(args[3]), &t); err != nil {
+			return FormatJSON(nil, errors.Wrap(err, "invalid permission"))
+		}
+	}
+	return FormatJSON(storage.ListPermissions(t.Credit))
+
+}
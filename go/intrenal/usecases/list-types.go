@@ -1,10 +1,33 @@
 package usecases
 
 import (
+	"encoding/json"
+
+	"github.com/pkg/errors"
+
 	"github.com/marcosbitetti/godot-manage-attribuitions-plugin/intrenal/infra"
 )
 
-func GetTypes(storage *infra.Storage,  _ []string) []byte {
-	return FormatJSON(storage.ListTypes())
+type typesRequest struct {
+	Tree bool `json:"tree"`
+}
+
+// GetTypes lists the types by name, nested under their parents when tree is
+// requested.
+func GetTypes(storage *infra.Storage, args []string) []byte {
+	request := typesRequest{}
+	if len(args) > 3 {
+		if err := json.Unmarshal([]byte(args[3]), &request); err != nil {
+			return FormatJSON(nil, errors.Wrap(err, "invalid types request"))
+		}
+	}
+	types, err := storage.ListTypes()
+	if err != nil {
+		return FormatJSON(nil, err)
+	}
+	if request.Tree {
+		return FormatJSON(typeTree(types), nil)
+	}
+	return FormatJSON(types, nil)
 
 }
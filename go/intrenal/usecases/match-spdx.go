@@ -0,0 +1,50 @@
+package usecases
+
+import (
+	"github.com/pkg/errors"
+
+	"github.com/marcosbitetti/godot-manage-attribuitions-plugin/intrenal/domain"
+	"github.com/marcosbitetti/godot-manage-attribuitions-plugin/intrenal/infra"
+)
+
+type matchSpdxReport struct {
+	Matched   []domain.Licence `json:"matched"`
+	Unmatched []domain.Licence `json:"unmatched"`
+}
+
+// MatchSpdx sets the SPDX identifier of the licences without one, guessing it
+// from their names. Licences that can't be recognized are reported back.
+func MatchSpdx(storage *infra.Storage, _ []string) []byte {
+	licences, err := storage.ListLicences()
+	if err != nil {
+		return FormatJSON(nil, err)
+	}
+	report := matchSpdxReport{
+		Matched:   make([]domain.Licence, 0),
+		Unmatched: make([]domain.Licence, 0),
+	}
+	for _, item := range licences {
+		if item.SpdxId != "" {
+			continue
+		}
+		id := guessSpdxId(item.Name)
+		if id == "" {
+			report.Unmatched = append(report.Unmatched, item)
+			continue
+		}
+		licence, err := storage.GetLicence(item.Id)
+		if err != nil {
+			return FormatJSON(nil, errors.Wrap(err, "error reading licence"))
+		}
+		if licence == nil {
+			return FormatJSON(nil, NewErrNotFound())
+		}
+		licence.SpdxId = id
+		if err := storage.UpdateLicence(*licence); err != nil {
+			return FormatJSON(nil, errors.Wrap(err, "error updating licence"))
+		}
+		item.SpdxId = id
+		report.Matched = append(report.Matched, item)
+	}
+	return FormatJSON(report, nil)
+}
@@ -0,0 +1,61 @@
+package usecases
+
+import (
+	"encoding/json"
+
+	"github.com/pkg/errors"
+
+	"github.com/marcosbitetti/godot-manage-attribuitions-plugin/intrenal/infra"
+)
+
+type mergeRequest struct {
+	Into int64   `json:"into"`
+	From []int64 `json:"from"`
+}
+
+type mergeReport struct {
+	Moved int64 `json:"moved"`
+}
+
+// MergeAuthors moves the credits of the "from" authors to the "into" author in
+// a single transaction. The merged names become aliases.
+func MergeAuthors(storage *infra.Storage, args []string) []byte {
+	if len(args) < 4 {
+		return FormatJSON(nil, NewErrMissingArgument())
+	}
+	var request mergeRequest
+	if err := json.Unmarshal([]byte(args[3]), &request); err != nil {
+		return FormatJSON(nil, errors.Wrap(err, "invalid merge"))
+	}
+	if request.Into == 0 || len(request.From) == 0 {
+		return FormatJSON(nil, NewErrInvalidValue())
+	}
+	request.From = uniqueIds(request.From)
+	for _, id := range append([]int64{request.Into}, request.From...) {
+		author, err := storage.GetAuthor(id)
+		if err != nil {
+			return FormatJSON(nil, errors.Wrap(err, "error merging authors"))
+		}
+		if author == nil {
+			return FormatJSON(nil, NewErrNotFound())
+		}
+	}
+	moved, err := storage.MergeAuthors(request.Into, request.From)
+	if err != nil {
+		return FormatJSON(nil, errors.Wrap(err, "error merging authors"))
+	}
+	return FormatJSON(mergeReport{Moved: moved}, nil)
+}
+
+// uniqueIds drops the repeated ids, a merged row is deleted on its first pass.
+func uniqueIds(ids []int64) []int64 {
+	seen := make(map[int64]bool)
+	unique := make([]int64, 0, len(ids))
+	for _, id := range ids {
+		if !seen[id] {
+			seen[id] = true
+			unique = append(unique, id)
+		}
+	}
+	return unique
+}
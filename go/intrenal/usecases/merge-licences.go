@@ -0,0 +1,39 @@
+package usecases
+
+import (
+	"encoding/json"
+
+	"github.com/pkg/errors"
+
+	"github.com/marcosbitetti/godot-manage-attribuitions-plugin/intrenal/infra"
+)
+
+// MergeLicences moves the credits of the "from" licences to the "into" licence
+// in a single transaction, and deletes the "from" licences.
+func MergeLicences(storage *infra.Storage, args []string) []byte {
+	if len(args) < 4 {
+		return FormatJSON(nil, NewErrMissingArgument())
+	}
+	var request mergeRequest
+	if err := json.Unmarshal([]byte(args[3]), &request); err != nil {
+		return FormatJSON(nil, errors.Wrap(err, "invalid merge"))
+	}
+	if request.Into == 0 || len(request.From) == 0 {
+		return FormatJSON(nil, NewErrInvalidValue())
+	}
+	request.From = uniqueIds(request.From)
+	for _, id := range append([]int64{request.Into}, request.From...) {
+		licence, err := storage.GetLicence(id)
+		if err != nil {
+			return FormatJSON(nil, errors.Wrap(err, "error merging licences"))
+		}
+		if licence == nil {
+			return FormatJSON(nil, NewErrNotFound())
+		}
+	}
+	moved, err := storage.MergeLicences(request.Into, request.From)
+	if err != nil {
+		return FormatJSON(nil, errors.Wrap(err, "error merging licences"))
+	}
+	return FormatJSON(mergeReport{Moved: moved}, nil)
+}
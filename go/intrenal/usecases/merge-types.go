@@ -0,0 +1,73 @@
+package usecases
+
+import (
+	"encoding/json"
+
+	"github.com/pkg/errors"
+
+	"github.com/marcosbitetti/godot-manage-attribuitions-plugin/intrenal/domain"
+	"github.com/marcosbitetti/godot-manage-attribuitions-plugin/intrenal/infra"
+)
+
+// MergeTypes moves the credits and the subtypes of the "from" types to the
+// "into" type in a single transaction, and deletes the "from" types. The
+// "into" type can't be a subtype of a "from" one, and the moved credits must
+// follow its rules.
+func MergeTypes(storage *infra.Storage, args []string) []byte {
+	if len(args) < 4 {
+		return FormatJSON(nil, NewErrMissingArgument())
+	}
+	var request mergeRequest
+	if err := json.Unmarshal([]byte(args[3]), &request); err != nil {
+		return FormatJSON(nil, errors.Wrap(err, "invalid merge"))
+	}
+	if request.Into == 0 || len(request.From) == 0 {
+		return FormatJSON(nil, NewErrInvalidValue())
+	}
+	request.From = uniqueIds(request.From)
+	merged := make(map[string]bool)
+	for _, id := range request.From {
+		type_, err := storage.GetType(id)
+		if err != nil {
+			return FormatJSON(nil, errors.Wrap(err, "error merging types"))
+		}
+		if type_ == nil {
+			return FormatJSON(nil, NewErrNotFound())
+		}
+		if id == request.Into {
+			continue
+		}
+		merged[type_.Name] = true
+		// the subtypes of the merged type move to the "into" type, it cant be one of them
+		if err := validateTypeParent(storage, domain.Type{Id: id, Parent: request.Into}); err != nil {
+			return FormatJSON(nil, err)
+		}
+	}
+	var moved int64
+	err := storage.Batch(func(batch *infra.Storage) error {
+		attribuitions, err := batch.FindAttribuitions(domain.Query{Order: "ASC"})
+		if err != nil {
+			return err
+		}
+		if moved, err = batch.MergeTypes(request.Into, request.From); err != nil {
+			return err
+		}
+		for _, attribuition := range attribuitions {
+			if !merged[attribuition.Type] {
+				continue
+			}
+			current, err := batch.GetAttribuition(attribuition.Id)
+			if err != nil {
+				return err
+			}
+			if err := validateAttribuition(batch, current); err != nil {
+				return errors.Wrapf(err, "attribuition %d breaks the rules of the type", attribuition.Id)
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return FormatJSON(nil, errors.Wrap(err, "error merging types"))
+	}
+	return FormatJSON(mergeReport{Moved: moved}, nil)
+}
@@ -0,0 +1,71 @@
+package usecases
+
+import (
+	"math"
+	"sort"
+
+	"github.com/marcosbitetti/godot-manage-attribuitions-plugin/intrenal/domain"
+	"github.com/marcosbitetti/godot-manage-attribuitions-plugin/intrenal/infra"
+)
+
+// PurchaseReport totals the spend on paid assets by source, licence and
+// month. Purchases without source or date are grouped as "Unknown".
+func PurchaseReport(storage *infra.Storage, _ []string) []byte {
+	attribuitions, err := storage.FindAttribuitions(domain.Query{Order: "ASC"})
+	if err != nil {
+		return FormatJSON(nil, err)
+	}
+	sources := make(purchaseTotals)
+	licences := make(purchaseTotals)
+	months := make(purchaseTotals)
+	for _, attribuition := range attribuitions {
+		purchase := attribuition.Purchase
+		if purchase == nil {
+			continue
+		}
+		month := unknownGroup
+		if len(purchase.Date) >= 7 {
+			month = purchase.Date[:7]
+		}
+		sources.add(orUnknown(attribuition.Source), purchase)
+		licences.add(orUnknown(attribuition.Licence), purchase)
+		months.add(month, purchase)
+	}
+	return FormatJSON(domain.PurchaseReport{
+		Sources:  sources.sorted(),
+		Licences: licences.sorted(),
+		Months:   months.sorted(),
+	}, nil)
+}
+
+type purchaseTotals map[string]*domain.PurchaseTotal
+
+func (p purchaseTotals) add(key string, purchase *domain.Purchase) {
+	total := p[key]
+	if total == nil {
+		total = &domain.PurchaseTotal{Key: key, Totals: make(map[string]float64)}
+		p[key] = total
+	}
+	total.Purchases++
+	if purchase.Price > 0 {
+		// prices are rounded to cents to avoid float noise in the sums
+		sum := total.Totals[purchase.Currency] + purchase.Price
+		total.Totals[purchase.Currency] = math.Round(sum*100) / 100
+	}
+}
+
+func (p purchaseTotals) sorted() []domain.PurchaseTotal {
+	list := make([]domain.PurchaseTotal, 0, len(p))
+	for _, total := range p {
+		list = append(list, *total)
+	}
+	sort.Slice(list, func(i, j int) bool { return list[i].Key < list[j].Key })
+	return list
+}
+
+func orUnknown(value string) string {
+	if value == "" {
+		return unknownGroup
+	}
+	return value
+}
@@ -0,0 +1,26 @@
+package usecases
+
+import (
+	"strings"
+
+	"github.com/marcosbitetti/godot-manage-attribuitions-plugin/intrenal/domain"
+)
+
+// validPurchase checks the purchase of a paid asset, the currency is an ISO
+// 4217 code and is normalized to upper case.
+func validPurchase(purchase *domain.Purchase) bool {
+	if purchase == nil {
+		return true
+	}
+	purchase.Currency = strings.ToUpper(strings.TrimSpace(purchase.Currency))
+	if purchase.Price < 0 || purchase.Seats < 0 {
+		return false
+	}
+	if purchase.Currency != "" && len(purchase.Currency) != 3 {
+		return false
+	}
+	if purchase.Price > 0 && purchase.Currency == "" {
+		return false
+	}
+	return validDate(purchase.Date)
+}
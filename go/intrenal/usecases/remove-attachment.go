@@ -0,0 +1,27 @@
+package usecases
+
+import (
+	"encoding/json"
+
+	"github.com/pkg/errors"
+
+	"github.com/marcosbitetti/godot-manage-attribuitions-plugin/intrenal/infra"
+)
+
+func RemoveAttachment(storage *infra.Storage, args []string) []byte {
+	if len(args) < 4 {
+		return FormatJSON(nil, NewErrMissingArgument())
+	}
+	var t attachmentRequest
+	if err := json.Unmarshal([]byte(args[3]), &t); err != nil {
+		return FormatJSON(nil, errors.Wrap(err, "invalid attachment"))
+	}
+	if t.Id == 0 {
+		return FormatJSON(nil, NewErrInvalidValue())
+	}
+	if err := storage.RemoveAttachment(t.Id); err != nil {
+		return FormatJSON(nil, errors.Wrap(err, "error removing attachment"))
+	}
+	return FormatJSON(SuccessMsg, nil)
+
+}
@@ -0,0 +1,236 @@
+package usecases
+
+import (
+	"bufio"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// reuseFinding is the copyright and licence information found for a file.
+type reuseFinding struct {
+	Copyrights []string
+	Licence    string
+	Source     string
+}
+
+// reuseAnnotation is a [[annotations]] table of a REUSE.toml file.
+type reuseAnnotation struct {
+	Paths      []string
+	Copyrights []string
+	Licence    string
+}
+
+var (
+	spdxCopyrightTag = regexp.MustCompile(`SPDX-FileCopyrightText:\s*(.*)$`)
+	spdxLicenceTag   = regexp.MustCompile(`SPDX-License-Identifier:\s*(.*)$`)
+	copyrightPrefix  = regexp.MustCompile(`(?i)^((copyright|\(c\)|©)\s*)+`)
+	copyrightYears   = regexp.MustCompile(`^[0-9]{4}([\s,\-–]+[0-9]{4})*[\s,]*`)
+	copyrightEmail   = regexp.MustCompile(`\s*<[^>]*>`)
+)
+
+// reuseHeaderExtensions lists the source files scanned for SPDX comment headers.
+var reuseHeaderExtensions = map[string]bool{
+	".gd":       true,
+	".gdshader": true,
+	".cs":       true,
+}
+
+// reuseIgnoredDirs lists directories never scanned for assets.
+var reuseIgnoredDirs = map[string]bool{
+	".git":     true,
+	".godot":   true,
+	".import":  true,
+	".reuse":   true,
+	"LICENSES": true,
+}
+
+// listProjectFiles walks a project returning slash separated paths relative to root.
+func listProjectFiles(root string) ([]string, error) {
+	files := make([]string, 0)
+	err := filepath.WalkDir(root, func(path string, entry os.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if entry.IsDir() {
+			if path != root && reuseIgnoredDirs[entry.Name()] {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		name := entry.Name()
+		if name == "REUSE.toml" || strings.HasSuffix(name, ".license") || strings.HasSuffix(name, ".import") {
+			return nil
+		}
+		rel, err := filepath.Rel(root, path)
+		if err != nil {
+			return err
+		}
+		files = append(files, filepath.ToSlash(rel))
+		return nil
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "cant walk project files")
+	}
+	return files, nil
+}
+
+// readSpdxTags collects SPDX tags from a sidecar file or a source header.
+func readSpdxTags(path string) (*reuseFinding, error) {
+	handler, err := os.Open(path)
+	if err != nil {
+		return nil, errors.Wrap(err, "cant open "+path)
+	}
+	defer handler.Close()
+
+	finding := reuseFinding{Copyrights: make([]string, 0)}
+	scanner := bufio.NewScanner(handler)
+	for scanner.Scan() {
+		line := scanner.Text()
+		if match := spdxCopyrightTag.FindStringSubmatch(line); match != nil {
+			finding.Copyrights = append(finding.Copyrights, cleanCommentTail(match[1]))
+		}
+		if match := spdxLicenceTag.FindStringSubmatch(line); match != nil {
+			finding.Licence = cleanCommentTail(match[1])
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, errors.Wrap(err, "cant read "+path)
+	}
+	if finding.Licence == "" && len(finding.Copyrights) == 0 {
+		return nil, nil
+	}
+	return &finding, nil
+}
+
+func cleanCommentTail(value string) string {
+	value = strings.TrimSpace(value)
+	value = strings.TrimSuffix(value, "*/")
+	value = strings.TrimSuffix(value, "-->")
+	return strings.TrimSpace(value)
+}
+
+// authorFromCopyrights extracts the holder names from copyright statements,
+// ex: "© 2021-2023 Jane Doe <jane@example.com>" => "Jane Doe"
+func authorFromCopyrights(copyrights []string) string {
+	authors := make([]string, 0)
+	for _, copyright := range copyrights {
+		author := copyrightPrefix.ReplaceAllString(strings.TrimSpace(copyright), "")
+		author = copyrightYears.ReplaceAllString(author, "")
+		author = strings.TrimSpace(copyrightEmail.ReplaceAllString(author, ""))
+		if author != "" {
+			authors = append(authors, author)
+		}
+	}
+	return strings.Join(authors, ", ")
+}
+
+// globToRegexp converts REUSE/DEP-5 globs to regular expressions. When
+// crossDirs is set a single star also matches path separators, as DEP-5 does.
+func globToRegexp(glob string, crossDirs bool) *regexp.Regexp {
+	var builder strings.Builder
+	builder.WriteString("^")
+	for i := 0; i < len(glob); i++ {
+		switch c := glob[i]; c {
+		case '*':
+			if crossDirs || (i+1 < len(glob) && glob[i+1] == '*') {
+				builder.WriteString(".*")
+				for i+1 < len(glob) && glob[i+1] == '*' {
+					i++
+				}
+			} else {
+				builder.WriteString("[^/]*")
+			}
+		case '?':
+			builder.WriteString("[^/]")
+		case '\\':
+			if i+1 < len(glob) {
+				i++
+				builder.WriteString(regexp.QuoteMeta(string(glob[i])))
+			}
+		default:
+			builder.WriteString(regexp.QuoteMeta(string(c)))
+		}
+	}
+	builder.WriteString("$")
+	return regexp.MustCompile(builder.String())
+}
+
+// parseReuseToml reads the [[annotations]] tables of a REUSE.toml file. Only the
+// subset of TOML used by REUSE is understood: strings, arrays of strings and
+// array of tables.
+func parseReuseToml(content string) ([]reuseAnnotation, error) {
+	annotations := make([]reuseAnnotation, 0)
+	var current *reuseAnnotation
+	lines := strings.Split(strings.ReplaceAll(content, "\r\n", "\n"), "\n")
+	for i := 0; i < len(lines); i++ {
+		line := strings.TrimSpace(lines[i])
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		if line == "[[annotations]]" {
+			annotations = append(annotations, reuseAnnotation{})
+			current = &annotations[len(annotations)-1]
+			continue
+		}
+		if strings.HasPrefix(line, "[") {
+			current = nil
+			continue
+		}
+		pos := strings.Index(line, "=")
+		if pos < 0 {
+			return nil, errors.Errorf("invalid REUSE.toml line %d", i+1)
+		}
+		key := strings.Trim(strings.TrimSpace(line[:pos]), `"`)
+		raw := strings.TrimSpace(line[pos+1:])
+		for strings.HasPrefix(raw, "[") && !strings.Contains(stripTomlStrings(raw), "]") && i+1 < len(lines) {
+			i++
+			raw += " " + strings.TrimSpace(lines[i])
+		}
+		values := parseTomlValue(raw)
+		if current == nil {
+			continue
+		}
+		switch key {
+		case "path":
+			current.Paths = values
+		case "SPDX-FileCopyrightText":
+			current.Copyrights = values
+		case "SPDX-License-Identifier":
+			if len(values) > 0 {
+				current.Licence = values[0]
+			}
+		}
+	}
+	return annotations, nil
+}
+
+var tomlString = regexp.MustCompile(`"((?:[^"\\]|\\.)*)"|'([^']*)'`)
+
+func stripTomlStrings(raw string) string {
+	return tomlString.ReplaceAllString(raw, "")
+}
+
+// parseTomlValue returns a string or an array of strings as a list.
+func parseTomlValue(raw string) []string {
+	values := make([]string, 0)
+	matches := tomlString.FindAllStringSubmatch(raw, -1)
+	if len(matches) == 0 {
+		if strings.HasPrefix(raw, "[") {
+			return values
+		}
+		return []string{strings.TrimSpace(strings.SplitN(raw, "#", 2)[0])}
+	}
+	for _, match := range matches {
+		if strings.HasPrefix(match[0], "'") {
+			values = append(values, match[2])
+			continue
+		}
+		value := strings.NewReplacer(`\"`, `"`, `\\`, `\`, `\n`, "\n", `\t`, "\t").Replace(match[1])
+		values = append(values, value)
+	}
+	return values
+}
@@ -0,0 +1,118 @@
+package usecases
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/pkg/errors"
+
+	"github.com/marcosbitetti/godot-manage-attribuitions-plugin/intrenal/domain"
+	"github.com/marcosbitetti/godot-manage-attribuitions-plugin/intrenal/infra"
+)
+
+type seedRequest struct {
+	// Pack is the name of a bundled pack or the path of a JSON or YAML file.
+	Pack string `json:"pack"`
+}
+
+// Init creates the database, the work is done when the storage is opened. A
+// new database starts with the packs given with --seed, see ParseSeeds.
+func Init(_ *infra.Storage, _ []string) []byte {
+	return FormatJSON(SuccessMsg, nil)
+}
+
+// Seed applies a seed pack to the database, adding the licences, types,
+// sources and custom fields it doesn't have yet.
+func Seed(storage *infra.Storage, args []string) []byte {
+	if len(args) < 4 {
+		return FormatJSON(nil, NewErrMissingArgument())
+	}
+	var request seedRequest
+	if err := json.Unmarshal([]byte(args[3]), &request); err != nil {
+		return FormatJSON(nil, errors.Wrap(err, "invalid seed"))
+	}
+	if request.Pack == "" {
+		return FormatJSON(nil, NewErrInvalidValue())
+	}
+	pack, err := LoadSeedPack(request.Pack)
+	if err != nil {
+		return FormatJSON(nil, err)
+	}
+	report, err := storage.ApplySeed(*pack)
+	if err != nil {
+		return FormatJSON(nil, errors.Wrap(err, "error applying seed pack"))
+	}
+	return FormatJSON(report, nil)
+}
+
+// GetSeeds lists the names of the seed packs bundled in the binary.
+func GetSeeds(_ *infra.Storage, _ []string) []byte {
+	return FormatJSON(infra.BundledSeeds(), nil)
+}
+
+// LoadSeedPack reads the bundled seed pack with the name, or else the JSON or
+// YAML file at the path, and checks it.
+func LoadSeedPack(name string) (*domain.SeedPack, error) {
+	pack, err := infra.BundledSeed(name)
+	if err != nil {
+		return nil, err
+	}
+	if pack == nil {
+		content, err := os.ReadFile(name)
+		if err != nil {
+			return nil, errors.Wrap(err, "cant read seed pack")
+		}
+		extension := strings.ToLower(filepath.Ext(name))
+		pack, err = infra.ParseSeedPack(content, extension == ".yaml" || extension == ".yml")
+		if err != nil {
+			return nil, err
+		}
+	}
+	if err := validateSeedPack(*pack); err != nil {
+		return nil, err
+	}
+	return pack, nil
+}
+
+// validateSeedPack checks the entries of a pack follow the rules of the ones
+// added one by one, and that no type is its own ancestor. The licences named
+// by the pack are checked when it's applied.
+func validateSeedPack(pack domain.SeedPack) error {
+	for _, licence := range pack.Licences {
+		if licence.Name == "" || licence.Link == "" || !validCopyleftScope(licence.CopyleftScope) {
+			return NewErrInvalidValue()
+		}
+	}
+	parents := make(map[string]string)
+	for _, type_ := range pack.Types {
+		if strings.TrimSpace(type_.Name) == "" {
+			return NewErrInvalidValue()
+		}
+		for _, requirement := range type_.Requires {
+			if !knownRequirement(requirement) {
+				return NewErrInvalidValue()
+			}
+		}
+		parents[strings.ToLower(type_.Name)] = strings.ToLower(type_.Parent)
+	}
+	for name := range parents {
+		for parent, steps := parents[name], 0; parent != ""; parent, steps = parents[parent], steps+1 {
+			if parent == name || steps > len(parents) {
+				return NewErrInvalidValue()
+			}
+		}
+	}
+	for _, source := range pack.Sources {
+		if source.Name == "" || !isUrl(source.BaseUrl) {
+			return NewErrInvalidValue()
+		}
+	}
+	for _, field := range pack.Fields {
+		if !validFieldDefinition(field) {
+			return NewErrInvalidValue()
+		}
+	}
+	return nil
+}
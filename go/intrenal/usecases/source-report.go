@@ -0,0 +1,38 @@
+package usecases
+
+import (
+	"github.com/marcosbitetti/godot-manage-attribuitions-plugin/intrenal/domain"
+	"github.com/marcosbitetti/godot-manage-attribuitions-plugin/intrenal/infra"
+)
+
+// unknownGroup names the group of the records missing the grouped field.
+const unknownGroup = "Unknown"
+
+// SourceReport counts the attribuitions of every source and their licences.
+// Attribuitions without source are reported as "Unknown".
+func SourceReport(storage *infra.Storage, _ []string) []byte {
+	sources, err := storage.ListSources()
+	if err != nil {
+		return FormatJSON(nil, err)
+	}
+	attribuitions, err := storage.FindAttribuitions(domain.Query{Order: "ASC"})
+	if err != nil {
+		return FormatJSON(nil, err)
+	}
+	reports := make([]*domain.SourceReport, 0, len(sources)+1)
+	bySource := make(map[string]*domain.SourceReport)
+	for _, source := range append(sources, domain.Source{Name: unknownGroup}) {
+		report := &domain.SourceReport{Source: source.Name, Licences: make(map[string]int)}
+		reports = append(reports, report)
+		bySource[source.Name] = report
+	}
+	for _, attribuition := range attribuitions {
+		report := bySource[attribuition.Source]
+		if report == nil {
+			report = bySource[unknownGroup]
+		}
+		report.Credits++
+		report.Licences[attribuition.Licence]++
+	}
+	return FormatJSON(reports, nil)
+}
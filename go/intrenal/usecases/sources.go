@@ -0,0 +1,41 @@
+package usecases
+
+import (
+	"strings"
+
+	"github.com/pkg/errors"
+
+	"github.com/marcosbitetti/godot-manage-attribuitions-plugin/intrenal/domain"
+	"github.com/marcosbitetti/godot-manage-attribuitions-plugin/intrenal/infra"
+)
+
+// resolveSource checks the informed source of an attribuition or detects it
+// from the link. When the licence is missing, the default licence of the
+// source is suggested.
+func resolveSource(storage *infra.Storage, attribuition *domain.Attribuition) error {
+	sources, err := storage.ListSources()
+	if err != nil {
+		return errors.Wrap(err, "error reading sources")
+	}
+	var source *domain.Source
+	if attribuition.Source != "" {
+		for i := range sources {
+			if strings.EqualFold(sources[i].Name, attribuition.Source) {
+				source = &sources[i]
+			}
+		}
+		if source == nil {
+			return NewErrInvalidValue()
+		}
+	} else {
+		source = infra.DetectSource(sources, attribuition.Link)
+	}
+	if source == nil {
+		return nil
+	}
+	attribuition.Source = source.Name
+	if attribuition.Licence == "" {
+		attribuition.Licence = source.DefaultLicence
+	}
+	return nil
+}
@@ -0,0 +1,131 @@
+package usecases
+
+import (
+	"regexp"
+	"strings"
+
+	"github.com/marcosbitetti/godot-manage-attribuitions-plugin/intrenal/domain"
+	"github.com/marcosbitetti/godot-manage-attribuitions-plugin/intrenal/infra"
+)
+
+// spdxAliases maps SPDX identifiers to the names used by the seeded licences
+// that can't be derived from the identifier itself. The first identifier of a
+// name is the one guessed by matchSpdx.
+var spdxAliases = []struct {
+	Id   string
+	Name string
+}{
+	{"Apache-2.0", "Apache License 2.0"},
+	{"GPL-3.0-or-later", "GNU General Public Licence"},
+	{"GPL-3.0-only", "GNU General Public Licence"},
+	{"GPL-3.0", "GNU General Public Licence"},
+	{"LGPL-3.0-or-later", "GNU Lesser General Public License (LGPL)"},
+	{"LGPL-3.0-only", "GNU Lesser General Public License (LGPL)"},
+	{"LGPL-3.0", "GNU Lesser General Public License (LGPL)"},
+	{"MPL-2.0", "Mozilla Public License 2.0"},
+	{"OFL-1.1", "Open Font License (OFL)"},
+}
+
+var (
+	ccShortName    = regexp.MustCompile(`\((CC0|CC [A-Z]+(-[A-Z]+)*) ([0-9.]+)\)`)
+	spdxIdentifier = regexp.MustCompile(`^[A-Za-z0-9.+-]+$`)
+	spdxRefInvalid = regexp.MustCompile(`[^A-Za-z0-9.-]+`)
+)
+
+// findLicenceBySpdx returns the licence matching a SPDX identifier, or nil.
+// Licences without an identifier are matched by their name.
+func findLicenceBySpdx(licences []domain.Licence, id string) *domain.Licence {
+	id = strings.TrimSpace(id)
+	if id == "" {
+		return nil
+	}
+	for i := range licences {
+		if strings.EqualFold(licences[i].SpdxId, id) {
+			return &licences[i]
+		}
+	}
+	candidates := []string{strings.TrimPrefix(id, "LicenseRef-")}
+	for _, alias := range spdxAliases {
+		if strings.EqualFold(alias.Id, id) {
+			candidates = append(candidates, alias.Name)
+		}
+	}
+	short := spdxShortName(id)
+	for i := range licences {
+		if licences[i].SpdxId != "" {
+			continue
+		}
+		for _, candidate := range candidates {
+			if strings.EqualFold(licences[i].Name, candidate) {
+				return &licences[i]
+			}
+		}
+		if short != "" && strings.Contains(licences[i].Name, "("+short+")") {
+			return &licences[i]
+		}
+	}
+	return nil
+}
+
+// spdxShortName turns Creative Commons identifiers into the short form used
+// inside the seeded names, ex: CC-BY-NC-SA-4.0 => CC BY-NC-SA 4.0
+func spdxShortName(id string) string {
+	if !strings.HasPrefix(id, "CC") {
+		return ""
+	}
+	pos := strings.LastIndex(id, "-")
+	if pos < 0 {
+		return ""
+	}
+	kind, version := id[:pos], id[pos+1:]
+	if kind == "CC0" {
+		return "CC0 " + version
+	}
+	if !strings.HasPrefix(kind, "CC-") {
+		return ""
+	}
+	return "CC " + strings.TrimPrefix(kind, "CC-") + " " + version
+}
+
+// guessSpdxId returns the catalog identifier matching a licence name, or an
+// empty string when the name isn't recognized.
+func guessSpdxId(name string) string {
+	for _, alias := range spdxAliases {
+		if alias.Name == name {
+			return alias.Id
+		}
+	}
+	if match := ccShortName.FindStringSubmatch(name); match != nil {
+		id := strings.ReplaceAll(match[1], " ", "-") + "-" + match[3]
+		if infra.FindSpdxLicence(id) != nil {
+			return id
+		}
+	}
+	if licence := infra.FindSpdxLicence(name); licence != nil {
+		return licence.SpdxId
+	}
+	return infra.FindSpdxByName(name)
+}
+
+// exportSpdxId returns the identifier written in exports, falling back to a
+// LicenseRef- identifier for custom licences.
+func exportSpdxId(spdxId string, name string) string {
+	if spdxId != "" {
+		return spdxId
+	}
+	if id := guessSpdxId(name); id != "" {
+		return id
+	}
+	if spdxIdentifier.MatchString(name) {
+		return name
+	}
+	return "LicenseRef-" + strings.Trim(spdxRefInvalid.ReplaceAllString(name, "-"), "-")
+}
+
+// validSpdxId accepts catalog identifiers and custom LicenseRef- identifiers.
+func validSpdxId(id string) bool {
+	if strings.HasPrefix(id, "LicenseRef-") {
+		return len(id) > len("LicenseRef-")
+	}
+	return infra.FindSpdxLicence(id) != nil
+}
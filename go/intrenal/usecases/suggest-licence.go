@@ -0,0 +1,35 @@
+package usecases
+
+import (
+	"encoding/json"
+
+	"github.com/pkg/errors"
+
+	"github.com/marcosbitetti/godot-manage-attribuitions-plugin/intrenal/domain"
+	"github.com/marcosbitetti/godot-manage-attribuitions-plugin/intrenal/infra"
+)
+
+type licenceSuggestion struct {
+	Source  string `json:"source"`
+	Licence string `json:"licence"`
+}
+
+// SuggestLicence detects the source of a link and suggests its default
+// licence, so a credit can be prefilled before it is added.
+func SuggestLicence(storage *infra.Storage, args []string) []byte {
+	if len(args) < 4 {
+		return FormatJSON(nil, NewErrMissingArgument())
+	}
+	var t domain.Attribuition
+	if err := json.Unmarshal([]byte(args[3]), &t); err != nil {
+		return FormatJSON(nil, errors.Wrap(err, "invalid link"))
+	}
+	if t.Link == "" {
+		return FormatJSON(nil, NewErrInvalidValue())
+	}
+	t.Source, t.Licence = "", ""
+	if err := resolveSource(storage, &t); err != nil {
+		return FormatJSON(nil, err)
+	}
+	return FormatJSON(licenceSuggestion{Source: t.Source, Licence: t.Licence}, nil)
+}
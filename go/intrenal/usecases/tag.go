@@ -0,0 +1,51 @@
+package usecases
+
+import (
+	"encoding/json"
+
+	"github.com/pkg/errors"
+
+	"github.com/marcosbitetti/godot-manage-attribuitions-plugin/intrenal/infra"
+)
+
+type tagRequest struct {
+	Credit int64    `json:"credit"`
+	Tags   []string `json:"tags"`
+}
+
+// Tag adds tags to the attribuition with the "credit" id, the tags missing
+// are created.
+func Tag(storage *infra.Storage, args []string) []byte {
+	return changeTags(storage, args, storage.TagCredit)
+}
+
+// Untag removes tags from the attribuition with the "credit" id.
+func Untag(storage *infra.Storage, args []string) []byte {
+	return changeTags(storage, args, storage.UntagCredit)
+}
+
+func changeTags(storage *infra.Storage, args []string, change func(credit int64, tags []string) error) []byte {
+	if len(args) < 4 {
+		return FormatJSON(nil, NewErrMissingArgument())
+	}
+	var t tagRequest
+	if err := json.Unmarshal([]byte(args[3]), &t); err != nil {
+		return FormatJSON(nil, errors.Wrap(err, "invalid tags"))
+	}
+	tags, ok := cleanTags(t.Tags)
+	if t.Credit == 0 || !ok {
+		return FormatJSON(nil, NewErrInvalidValue())
+	}
+	attribuition, err := storage.GetAttribuition(t.Credit)
+	if err != nil {
+		return FormatJSON(nil, errors.Wrap(err, "error changing tags"))
+	}
+	if attribuition == nil {
+		return FormatJSON(nil, NewErrNotFound())
+	}
+	if err := change(t.Credit, tags); err != nil {
+		return FormatJSON(nil, errors.Wrap(err, "error changing tags"))
+	}
+	return FormatJSON(SuccessMsg, nil)
+
+}
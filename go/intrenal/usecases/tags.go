@@ -0,0 +1,42 @@
+package usecases
+
+import (
+	"strings"
+
+	"github.com/marcosbitetti/godot-manage-attribuitions-plugin/intrenal/domain"
+)
+
+// TagPlaceholder marks the assets that won't ship, they are left out of the
+// exports by default.
+const TagPlaceholder = "placeholder"
+
+// tagRules selects the attribuitions of an export by tag. The placeholders
+// are always excluded, unless they are included explicitly.
+type tagRules struct {
+	IncludeTags []string `json:"includeTags"`
+	ExcludeTags []string `json:"excludeTags"`
+}
+
+func (r tagRules) query() domain.Query {
+	query := domain.Query{Order: "ASC", Tags: r.IncludeTags, ExcludeTags: r.ExcludeTags}
+	for _, tag := range r.IncludeTags {
+		if strings.EqualFold(tag, TagPlaceholder) {
+			return query
+		}
+	}
+	query.ExcludeTags = append(query.ExcludeTags, TagPlaceholder)
+	return query
+}
+
+// cleanTags trims the tag names, ok is false when one of them is empty.
+func cleanTags(tags []string) ([]string, bool) {
+	cleaned := make([]string, 0, len(tags))
+	for _, tag := range tags {
+		tag = strings.TrimSpace(tag)
+		if tag == "" {
+			return nil, false
+		}
+		cleaned = append(cleaned, tag)
+	}
+	return cleaned, len(cleaned) > 0
+}
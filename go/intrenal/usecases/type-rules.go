@@ -0,0 +1,97 @@
+package usecases
+
+import (
+	"strings"
+
+	"github.com/pkg/errors"
+
+	"github.com/marcosbitetti/godot-manage-attribuitions-plugin/intrenal/domain"
+	"github.com/marcosbitetti/godot-manage-attribuitions-plugin/intrenal/infra"
+)
+
+// validateAttribuition checks an attribuition against the rules of its type.
+// Every attribuition needs a name, a known type and a licence, the ones
+// without licence get the default licence of the type, and the type adds its
+// own requirements. The custom fields are checked against their definitions.
+func validateAttribuition(storage *infra.Storage, attribuition *domain.Attribuition) error {
+	if attribuition.Name == "" ||
+		attribuition.Type == "" ||
+		!validPurchase(attribuition.Purchase) ||
+		!validDate(attribuition.ValidUntil) || !validDate(attribuition.RenewBy) {
+		return NewErrInvalidValue()
+	}
+	type_, err := storage.FindType(attribuition.Type)
+	if err != nil {
+		return errors.Wrap(err, "error reading type")
+	}
+	if type_ == nil {
+		return NewErrInvalidValue()
+	}
+	attribuition.Type = type_.Name
+	if attribuition.Licence == "" {
+		attribuition.Licence = type_.DefaultLicence
+	}
+	if attribuition.Licence == "" {
+		return NewErrInvalidValue()
+	}
+	for _, requirement := range type_.Requires {
+		ok, err := meetsRequirement(storage, *attribuition, requirement)
+		if err != nil {
+			return err
+		}
+		if !ok {
+			return NewErrInvalidValue()
+		}
+	}
+	return validateFields(storage, attribuition)
+}
+
+func meetsRequirement(storage *infra.Storage, attribuition domain.Attribuition, requirement string) (bool, error) {
+	switch requirement {
+	case domain.RequireLink:
+		return attribuition.Link != "", nil
+	case domain.RequireAuthor:
+		return attribuition.Author != "" || len(attribuition.Authors) > 0, nil
+	case domain.RequireFileName:
+		return attribuition.FileName != "", nil
+	case domain.RequireSource:
+		return attribuition.Source != "", nil
+	case domain.RequireLicenceText:
+		licence, err := storage.FindLicence(attribuition.Licence)
+		if err != nil {
+			return false, errors.Wrap(err, "error reading licence")
+		}
+		return licence != nil && strings.TrimSpace(licence.Text) != "", nil
+	}
+	return true, nil
+}
+
+// validateTypeRules checks the requirements of a type are known and its
+// default licence exists.
+func validateTypeRules(storage *infra.Storage, t domain.Type) error {
+	for _, requirement := range t.Requires {
+		if !knownRequirement(requirement) {
+			return NewErrInvalidValue()
+		}
+	}
+	if t.DefaultLicence == "" {
+		return nil
+	}
+	licence, err := storage.FindLicence(t.DefaultLicence)
+	if err != nil {
+		return errors.Wrap(err, "error reading licence")
+	}
+	if licence == nil {
+		return NewErrInvalidValue()
+	}
+	return nil
+}
+
+func knownRequirement(requirement string) bool {
+	for _, known := range domain.Requirements {
+		if requirement == known {
+			return true
+		}
+	}
+	return false
+}
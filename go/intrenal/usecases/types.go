@@ -0,0 +1,61 @@
+package usecases
+
+import (
+	"github.com/marcosbitetti/godot-manage-attribuitions-plugin/intrenal/domain"
+	"github.com/marcosbitetti/godot-manage-attribuitions-plugin/intrenal/infra"
+)
+
+// typeTree nests the types under their parents, the ones with a missing
+// parent stay at the top level.
+func typeTree(types []domain.Type) []domain.Type {
+	known := make(map[int64]bool)
+	for _, t := range types {
+		known[t.Id] = true
+	}
+	children := make(map[int64][]domain.Type)
+	for _, t := range types {
+		parent := t.Parent
+		if !known[parent] {
+			parent = 0
+		}
+		children[parent] = append(children[parent], t)
+	}
+	var nest func(parent int64) []domain.Type
+	nest = func(parent int64) []domain.Type {
+		list := children[parent]
+		for i := range list {
+			list[i].Children = nest(list[i].Id)
+		}
+		return list
+	}
+	tree := nest(0)
+	if tree == nil {
+		return []domain.Type{}
+	}
+	return tree
+}
+
+// validateTypeParent checks the parent of a type exists and isn't the type
+// itself or one of its descendants.
+func validateTypeParent(storage *infra.Storage, t domain.Type) error {
+	if t.Parent == 0 {
+		return nil
+	}
+	types, err := storage.ListTypes()
+	if err != nil {
+		return err
+	}
+	parents := make(map[int64]int64)
+	for _, other := range types {
+		parents[other.Id] = other.Parent
+	}
+	if _, ok := parents[t.Parent]; !ok {
+		return NewErrNotFound()
+	}
+	for id, steps := t.Parent, 0; id != 0 && steps <= len(types); id, steps = parents[id], steps+1 {
+		if id == t.Id {
+			return NewErrInvalidValue()
+		}
+	}
+	return nil
+}
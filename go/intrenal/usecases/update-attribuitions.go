@@ -17,14 +17,44 @@ func UpdateAttribuition(storage *infra.Storage, args []string) []byte {
 	if err := json.Unmarshal([]byte(args[3]), &t); err != nil {
 		return FormatJSON(nil, errors.Wrap(err, "invalid type"))
 	}
-	if t.Name == "" || t.Link == "" || t.Author == "" || t.FileName == "" ||
-		t.Type == "" || t.Licence == "" ||
-		t.Id == 0 {
+	if t.Id == 0 {
 		return FormatJSON(nil, NewErrInvalidValue())
 	}
-	if err := storage.UpdateAttribuition(t.Id, t.Name, t.FileName, t.Author, t.Link, t.Type, t.Licence); err != nil {
+	// fields omitted, like the attribution override, keep the stored values
+	current, err := storage.GetAttribuition(t.Id)
+	if err != nil {
+		return FormatJSON(nil, errors.Wrap(err, "error updating attribuition"))
+	}
+	if current == nil {
+		return FormatJSON(nil, NewErrNotFound())
+	}
+	if err := mergeAttribuition(storage, current, t, args[3]); err != nil {
+		return FormatJSON(nil, err)
+	}
+	if err := storage.UpdateAttribuition(*current); err != nil {
 		return FormatJSON(nil, errors.Wrap(err, "error updating attribuition"))
 	}
 	return FormatJSON(SuccessMsg, nil)
 
 }
+
+// mergeAttribuition applies the payload over the current attribuition, fields
+// omitted keep the stored values, and checks the result.
+func mergeAttribuition(storage *infra.Storage, current *domain.Attribuition, t domain.Attribuition, payload string) error {
+	author, link := current.Author, current.Link
+	if err := json.Unmarshal([]byte(payload), current); err != nil {
+		return errors.Wrap(err, "invalid type")
+	}
+	// a changed author name without authors replaces the linked authors
+	if t.Authors == nil && current.Author != author {
+		current.Authors = nil
+	}
+	// a changed link without source is detected again
+	if t.Source == "" && current.Link != link {
+		current.Source = ""
+	}
+	if err := resolveSource(storage, current); err != nil {
+		return err
+	}
+	return validateAttribuition(storage, current)
+}
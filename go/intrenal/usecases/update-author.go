@@ -0,0 +1,44 @@
+package usecases
+
+import (
+	"encoding/json"
+	"strings"
+
+	"github.com/pkg/errors"
+
+	"github.com/marcosbitetti/godot-manage-attribuitions-plugin/intrenal/domain"
+	"github.com/marcosbitetti/godot-manage-attribuitions-plugin/intrenal/infra"
+)
+
+// UpdateAuthor changes an author, omitted fields keep the stored values. The
+// credits of the author show the new name.
+func UpdateAuthor(storage *infra.Storage, args []string) []byte {
+	if len(args) < 4 {
+		return FormatJSON(nil, NewErrMissingArgument())
+	}
+	var t domain.Author
+	if err := json.Unmarshal([]byte(args[3]), &t); err != nil {
+		return FormatJSON(nil, errors.Wrap(err, "invalid author"))
+	}
+	if t.Id == 0 {
+		return FormatJSON(nil, NewErrInvalidValue())
+	}
+	current, err := storage.GetAuthor(t.Id)
+	if err != nil {
+		return FormatJSON(nil, errors.Wrap(err, "error updating author"))
+	}
+	if current == nil {
+		return FormatJSON(nil, NewErrNotFound())
+	}
+	if err := json.Unmarshal([]byte(args[3]), current); err != nil {
+		return FormatJSON(nil, errors.Wrap(err, "invalid author"))
+	}
+	if strings.TrimSpace(current.Name) == "" {
+		return FormatJSON(nil, NewErrInvalidValue())
+	}
+	if err := storage.UpdateAuthor(*current); err != nil {
+		return FormatJSON(nil, errors.Wrap(err, "error updating author"))
+	}
+	return FormatJSON(SuccessMsg, nil)
+
+}
@@ -0,0 +1,53 @@
+package usecases
+
+import (
+	"encoding/json"
+
+	"github.com/pkg/errors"
+
+	"github.com/marcosbitetti/godot-manage-attribuitions-plugin/intrenal/domain"
+	"github.com/marcosbitetti/godot-manage-attribuitions-plugin/intrenal/infra"
+)
+
+// UpdateField changes a custom field, omitted fields keep the stored values.
+// The type can't change while attribuitions have values for the field.
+func UpdateField(storage *infra.Storage, args []string) []byte {
+	if len(args) < 4 {
+		return FormatJSON(nil, NewErrMissingArgument())
+	}
+	var t domain.FieldDefinition
+	if err := json.Unmarshal([]byte(args[3]), &t); err != nil {
+		return FormatJSON(nil, errors.Wrap(err, "invalid field"))
+	}
+	if t.Id == 0 {
+		return FormatJSON(nil, NewErrInvalidValue())
+	}
+	current, err := storage.GetField(t.Id)
+	if err != nil {
+		return FormatJSON(nil, errors.Wrap(err, "error updating field"))
+	}
+	if current == nil {
+		return FormatJSON(nil, NewErrNotFound())
+	}
+	type_ := current.Type
+	if err := json.Unmarshal([]byte(args[3]), current); err != nil {
+		return FormatJSON(nil, errors.Wrap(err, "invalid field"))
+	}
+	if !validFieldDefinition(*current) {
+		return FormatJSON(nil, NewErrInvalidValue())
+	}
+	if current.Type != type_ {
+		values, err := storage.FieldValues(current.Id)
+		if err != nil {
+			return FormatJSON(nil, errors.Wrap(err, "error updating field"))
+		}
+		if values > 0 {
+			return FormatJSON(nil, NewErrInvalidValue())
+		}
+	}
+	if err := storage.UpdateField(*current); err != nil {
+		return FormatJSON(nil, errors.Wrap(err, "error updating field"))
+	}
+	return FormatJSON(SuccessMsg, nil)
+
+}
@@ -9,18 +9,63 @@ import (
 	"github.com/marcosbitetti/godot-manage-attribuitions-plugin/intrenal/infra"
 )
 
+// updateLicenceRequest keeps the stored SPDX id, text, summary, attribution
+// template and terms when they are omitted.
+type updateLicenceRequest struct {
+	domain.Licence
+	SpdxId              *string `json:"spdx"`
+	Text                *string `json:"text"`
+	Summary             *string `json:"summary"`
+	AttributionTemplate *string `json:"attributionTemplate"`
+}
+
 func UpdateLicence(storage *infra.Storage, args []string) []byte {
 	if len(args) < 4 {
 		return FormatJSON(nil, NewErrMissingArgument())
 	}
-	var t domain.Licence
+	var t updateLicenceRequest
 	if err := json.Unmarshal([]byte(args[3]), &t); err != nil {
 		return FormatJSON(nil, errors.Wrap(err, "invalid type"))
 	}
 	if t.Name == "" || t.Link == "" || t.Id == 0 {
 		return FormatJSON(nil, NewErrInvalidValue())
 	}
-	if err := storage.UpdateLicence(t.Id, t.Name, t.Link); err != nil {
+	current, err := storage.GetLicence(t.Id)
+	if err != nil {
+		return FormatJSON(nil, errors.Wrap(err, "error updating licence"))
+	}
+	if current == nil {
+		return FormatJSON(nil, NewErrNotFound())
+	}
+	if t.SpdxId != nil {
+		if *t.SpdxId != "" && !validSpdxId(*t.SpdxId) {
+			return FormatJSON(nil, NewErrInvalidValue())
+		}
+		spdxId := infra.NormalizeSpdxId(*t.SpdxId)
+		if spdxId != current.SpdxId {
+			// a new id brings its known terms, the informed ones are read over them
+			current.LicenceTerms = infra.LicenceTermsFor(spdxId, t.Name)
+		}
+		current.SpdxId = spdxId
+	}
+	if t.Text != nil {
+		current.Text = *t.Text
+	}
+	if t.Summary != nil {
+		current.Summary = *t.Summary
+	}
+	if t.AttributionTemplate != nil {
+		current.AttributionTemplate = *t.AttributionTemplate
+	}
+	current.Name = t.Name
+	current.Link = t.Link
+	if err := json.Unmarshal([]byte(args[3]), &current.LicenceTerms); err != nil {
+		return FormatJSON(nil, errors.Wrap(err, "invalid type"))
+	}
+	if !validCopyleftScope(current.CopyleftScope) {
+		return FormatJSON(nil, NewErrInvalidValue())
+	}
+	if err := storage.UpdateLicence(*current); err != nil {
 		return FormatJSON(nil, errors.Wrap(err, "error updating licence"))
 	}
 	return FormatJSON(SuccessMsg, nil)
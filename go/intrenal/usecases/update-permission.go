@@ -0,0 +1,43 @@
+package usecases
+
+import (
+	"encoding/json"
+
+	"github.com/pkg/errors"
+
+	"github.com/marcosbitetti/godot-manage-attribuitions-plugin/intrenal/domain"
+	"github.com/marcosbitetti/godot-manage-attribuitions-plugin/intrenal/infra"
+)
+
+// UpdatePermission changes a permission, omitted fields keep the stored
+// values. A permission can't be moved to another attribuition.
+func UpdatePermission(storage *infra.Storage, args []string) []byte {
+	if len(args) < 4 {
+		return FormatJSON(nil, NewErrMissingArgument())
+	}
+	var t domain.Permission
+	if err := json.Unmarshal([]byte(args[3]), &t); err != nil {
+		return FormatJSON(nil, errors.Wrap(err, "invalid permission"))
+	}
+	if t.Id == 0 {
+		return FormatJSON(nil, NewErrInvalidValue())
+	}
+	current, err := storage.GetPermission(t.Id)
+	if err != nil {
+		return FormatJSON(nil, errors.Wrap(err, "error updating permission"))
+	}
+	if current == nil {
+		return FormatJSON(nil, NewErrNotFound())
+	}
+	if err := json.Unmarshal([]byte(args[3]), current); err != nil {
+		return FormatJSON(nil, errors.Wrap(err, "invalid permission"))
+	}
+	if !validPermission(*current) {
+		return FormatJSON(nil, NewErrInvalidValue())
+	}
+	if err := storage.UpdatePermission(*current); err != nil {
+		return FormatJSON(nil, errors.Wrap(err, "error updating permission"))
+	}
+	return FormatJSON(SuccessMsg, nil)
+
+}
@@ -0,0 +1,40 @@
+package usecases
+
+import (
+	"encoding/json"
+	"strings"
+
+	"github.com/pkg/errors"
+
+	"github.com/marcosbitetti/godot-manage-attribuitions-plugin/intrenal/infra"
+)
+
+// UpdateProfile changes the project profile, omitted fields keep their stored
+// values. The licence is the SPDX id of the project, empty for closed source.
+func UpdateProfile(storage *infra.Storage, args []string) []byte {
+	if len(args) < 4 {
+		return FormatJSON(nil, NewErrMissingArgument())
+	}
+	profile, err := storage.GetProfile()
+	if err != nil {
+		return FormatJSON(nil, errors.Wrap(err, "error updating profile"))
+	}
+	if err := json.Unmarshal([]byte(args[3]), &profile); err != nil {
+		return FormatJSON(nil, errors.Wrap(err, "invalid profile"))
+	}
+	if profile.Licence != "" && !validSpdxId(profile.Licence) {
+		return FormatJSON(nil, NewErrInvalidValue())
+	}
+	profile.Licence = infra.NormalizeSpdxId(profile.Licence)
+	for i, platform := range profile.Platforms {
+		profile.Platforms[i] = strings.TrimSpace(platform)
+		if profile.Platforms[i] == "" || strings.Contains(platform, ",") {
+			return FormatJSON(nil, NewErrInvalidValue())
+		}
+	}
+	if err := storage.UpdateProfile(profile); err != nil {
+		return FormatJSON(nil, errors.Wrap(err, "error updating profile"))
+	}
+	return FormatJSON(SuccessMsg, nil)
+
+}
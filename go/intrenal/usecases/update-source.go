@@ -0,0 +1,42 @@
+package usecases
+
+import (
+	"encoding/json"
+
+	"github.com/pkg/errors"
+
+	"github.com/marcosbitetti/godot-manage-attribuitions-plugin/intrenal/domain"
+	"github.com/marcosbitetti/godot-manage-attribuitions-plugin/intrenal/infra"
+)
+
+// UpdateSource changes a source, omitted fields keep the stored values.
+func UpdateSource(storage *infra.Storage, args []string) []byte {
+	if len(args) < 4 {
+		return FormatJSON(nil, NewErrMissingArgument())
+	}
+	var t domain.Source
+	if err := json.Unmarshal([]byte(args[3]), &t); err != nil {
+		return FormatJSON(nil, errors.Wrap(err, "invalid source"))
+	}
+	if t.Id == 0 {
+		return FormatJSON(nil, NewErrInvalidValue())
+	}
+	current, err := storage.GetSource(t.Id)
+	if err != nil {
+		return FormatJSON(nil, errors.Wrap(err, "error updating source"))
+	}
+	if current == nil {
+		return FormatJSON(nil, NewErrNotFound())
+	}
+	if err := json.Unmarshal([]byte(args[3]), current); err != nil {
+		return FormatJSON(nil, errors.Wrap(err, "invalid source"))
+	}
+	if err := validateSource(storage, *current); err != nil {
+		return FormatJSON(nil, err)
+	}
+	if err := storage.UpdateSource(*current); err != nil {
+		return FormatJSON(nil, errors.Wrap(err, "error updating source"))
+	}
+	return FormatJSON(SuccessMsg, nil)
+
+}
@@ -9,6 +9,7 @@ import (
 	"github.com/marcosbitetti/godot-manage-attribuitions-plugin/intrenal/infra"
 )
 
+// UpdateType changes a type, omitted fields keep the stored values.
 func UpdateType(storage *infra.Storage, args []string) []byte {
 	if len(args) < 4 {
 		return FormatJSON(nil, NewErrMissingArgument())
@@ -17,10 +18,29 @@ func UpdateType(storage *infra.Storage, args []string) []byte {
 	if err := json.Unmarshal([]byte(args[3]), &t); err != nil {
 		return FormatJSON(nil, errors.Wrap(err, "invalid type"))
 	}
-	if t.Name == "" || t.Id == 0 {
+	if t.Id == 0 {
 		return FormatJSON(nil, NewErrInvalidValue())
 	}
-	if err := storage.UpdateType(t.Id, t.Name); err != nil {
+	current, err := storage.GetType(t.Id)
+	if err != nil {
+		return FormatJSON(nil, errors.Wrap(err, "error updating type"))
+	}
+	if current == nil {
+		return FormatJSON(nil, NewErrNotFound())
+	}
+	if err := json.Unmarshal([]byte(args[3]), current); err != nil {
+		return FormatJSON(nil, errors.Wrap(err, "invalid type"))
+	}
+	if current.Name == "" {
+		return FormatJSON(nil, NewErrInvalidValue())
+	}
+	if err := validateTypeRules(storage, *current); err != nil {
+		return FormatJSON(nil, err)
+	}
+	if err := validateTypeParent(storage, *current); err != nil {
+		return FormatJSON(nil, err)
+	}
+	if err := storage.UpdateType(*current); err != nil {
 		return FormatJSON(nil, errors.Wrap(err, "error updating type"))
 	}
 	return FormatJSON(SuccessMsg, nil)
@@ -0,0 +1,101 @@
+package usecases
+
+import (
+	"bytes"
+	"encoding/json"
+
+	"github.com/pkg/errors"
+
+	"github.com/marcosbitetti/godot-manage-attribuitions-plugin/intrenal/domain"
+	"github.com/marcosbitetti/godot-manage-attribuitions-plugin/intrenal/infra"
+)
+
+const (
+	UpsertCreated   = "created"
+	UpsertUpdated   = "updated"
+	UpsertUnchanged = "unchanged"
+)
+
+type upsertRequest struct {
+	// Key is the natural key the attribuition is matched by, one of
+	// domain.AttribuitionKeys, the filename when empty.
+	Key string `json:"key"`
+	domain.Attribuition
+}
+
+// Upserted tells what upsertAttribuition did with the attribuition.
+type Upserted struct {
+	Id     int64  `json:"_id"`
+	Result string `json:"result"`
+}
+
+// UpsertAttribuition adds the attribuition when none has the same key, else
+// updates the one found like updateAttribuition. Running it again with the
+// same payload changes nothing.
+func UpsertAttribuition(storage *infra.Storage, args []string) []byte {
+	if len(args) < 4 {
+		return FormatJSON(nil, NewErrMissingArgument())
+	}
+	var t upsertRequest
+	if err := json.Unmarshal([]byte(args[3]), &t); err != nil {
+		return FormatJSON(nil, errors.Wrap(err, "invalid type"))
+	}
+	if t.Key == "" {
+		t.Key = domain.KeyFileName
+	}
+	if t.Id != 0 || !validAttribuitionKey(t.Key, t.Attribuition) {
+		return FormatJSON(nil, NewErrInvalidValue())
+	}
+	current, err := storage.FindAttribuitionByKey(t.Key, t.Attribuition)
+	if err != nil {
+		return FormatJSON(nil, errors.Wrap(err, "error upserting attribuition"))
+	}
+
+	if current == nil {
+		if err := resolveSource(storage, &t.Attribuition); err != nil {
+			return FormatJSON(nil, err)
+		}
+		if err := validateAttribuition(storage, &t.Attribuition); err != nil {
+			return FormatJSON(nil, err)
+		}
+		id, err := storage.AddAttribuition(t.Attribuition)
+		if err != nil {
+			return FormatJSON(nil, errors.Wrap(err, "error upserting attribuition"))
+		}
+		return FormatJSON(Upserted{Id: id, Result: UpsertCreated}, nil)
+	}
+
+	before, err := json.Marshal(current)
+	if err != nil {
+		return FormatJSON(nil, errors.Wrap(err, "error upserting attribuition"))
+	}
+	if err := mergeAttribuition(storage, current, t.Attribuition, args[3]); err != nil {
+		return FormatJSON(nil, err)
+	}
+	after, err := json.Marshal(current)
+	if err != nil {
+		return FormatJSON(nil, errors.Wrap(err, "error upserting attribuition"))
+	}
+	if bytes.Equal(before, after) {
+		return FormatJSON(Upserted{Id: current.Id, Result: UpsertUnchanged}, nil)
+	}
+	if err := storage.UpdateAttribuition(*current); err != nil {
+		return FormatJSON(nil, errors.Wrap(err, "error upserting attribuition"))
+	}
+	return FormatJSON(Upserted{Id: current.Id, Result: UpsertUpdated}, nil)
+
+}
+
+// validAttribuitionKey tells if the key is known and the attribuition has a
+// value for it.
+func validAttribuitionKey(key string, attribuition domain.Attribuition) bool {
+	switch key {
+	case domain.KeyFileName:
+		return attribuition.FileName != ""
+	case domain.KeyExternalId:
+		return attribuition.ExternalId != ""
+	case domain.KeyLink:
+		return attribuition.Link != "" && attribuition.Name != ""
+	}
+	return false
+}